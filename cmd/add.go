@@ -111,6 +111,9 @@ var addCmd = &cobra.Command{
 
 		if mustBool(cmd.LocalFlags().GetBool("all")) {
 			git.Add(".")
+			if isJSONOutput() {
+				printJSON(AddOutput{git.StagedFiles()})
+			}
 			return
 		}
 
@@ -135,7 +138,7 @@ var addCmd = &cobra.Command{
 				Options: labels,
 			}
 			// exit on prompt interrupted
-			must(survey.AskOne(prompt, &selectedLabels))
+			must(survey.AskOne(prompt, &selectedLabels, askOpts()...))
 
 			for _, label := range selectedLabels {
 				code := codeDict[label]
@@ -152,6 +155,10 @@ var addCmd = &cobra.Command{
 				}
 			}
 		}
+
+		if isJSONOutput() {
+			printJSON(AddOutput{git.StagedFiles()})
+		}
 	},
 }
 
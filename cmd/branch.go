@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	// BranchPatternTmpl default template of branch names, i.e. `feat/ABC-12-api-token-refresh`
+	BranchPatternTmpl = `{{.Type}}/{{if .Ticket}}{{.Ticket}}-{{end}}{{if .Scope}}{{.Scope}}-{{end}}{{.Description}}`
+	// BranchSlugMaxLen max length of description slug in branch names
+	BranchSlugMaxLen = 50
+)
+
+var slugInvalidPattern = regexp.MustCompile(`[^a-z0-9]+`)
+var ticketInvalidPattern = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// Slugify lower case words of s joined by "-", truncated at word boundary to maxLen
+func Slugify(s string, maxLen int) string {
+	slug := strings.Trim(slugInvalidPattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if maxLen > 0 && len(slug) > maxLen {
+		slug = slug[:maxLen]
+		if i := strings.LastIndex(slug, "-"); i > 0 {
+			slug = slug[:i]
+		}
+	}
+	return slug
+}
+
+// BranchData data of branch name template, slugified
+type BranchData struct {
+	Type        string
+	Scope       string
+	Ticket      string // case kept for ticket patterns, i.e. `ABC-12`
+	Description string
+}
+
+// NewBranchData slugify branch name components
+func NewBranchData(cmtType, scope, ticket, desc string) BranchData {
+	return BranchData{
+		Type:        Slugify(cmtType, 0),
+		Scope:       Slugify(scope, 0),
+		Ticket:      strings.Trim(ticketInvalidPattern.ReplaceAllString(ticket, "-"), "-"),
+		Description: Slugify(desc, BranchSlugMaxLen),
+	}
+}
+
+// RenderBranch execute gitwok.branch.pattern with data
+func RenderBranch(data BranchData) (string, error) {
+	tmpl, err := newTmpl("branch").Parse(viper.GetString("gitwok.branch.pattern"))
+	if err != nil {
+		return "", fmt.Errorf("branch pattern is invalid: %v", err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("branch pattern is invalid: %v", err)
+	}
+	return sb.String(), nil
+}
+
+// BranchName branch name of commit msg by gitwok.branch.pattern, i.e. `feat/api-add-login`
+func BranchName(cm *CommitMsg) (string, error) {
+	return RenderBranch(NewBranchData(cm.Type, cm.Scope, "", cm.Description))
+}
+
+// BranchNamePattern gitwok.branch.match regex of conforming branch names,
+// defaults to `<type>/<slug>` of configured types
+func BranchNamePattern() (*regexp.Regexp, error) {
+	if match := viper.GetString("gitwok.branch.match"); match != "" {
+		return regexp.Compile(match)
+	}
+
+	types := []string{}
+	for _, t := range TypeOptions() {
+		types = append(types, regexp.QuoteMeta(Slugify(t, 0)))
+	}
+	return regexp.Compile(fmt.Sprintf(`^(%s)/[A-Za-z0-9][A-Za-z0-9._-]*$`, strings.Join(types, "|")))
+}
+
+// CheckBranchName check branch name conforms to BranchNamePattern, branches of
+// gitwok.branch.ignore and protected branches are not checked
+// @return msg {string} "" if conforming
+func CheckBranchName(branch string) string {
+	if branch == "" || IsProtected(branch) || MatchAnyGlob(viper.GetStringSlice("gitwok.branch.ignore"), branch) {
+		return ""
+	}
+
+	pattern, err := BranchNamePattern()
+	if err != nil {
+		return fmt.Sprintf("branch pattern is invalid: %v", err)
+	}
+	if !pattern.MatchString(branch) {
+		return fmt.Sprintf("branch name %q must match %s", branch, pattern)
+	}
+	return ""
+}
+
+// createBranch create and switch to branch, changes are carried over
+func createBranch(git *Git, branch string) error {
+	if git.dryRun {
+		logger.Info("git checkout -b", branch)
+		return nil
+	}
+	if _, err := git.run("checkout", "-b", branch); err != nil {
+		return err
+	}
+	logger.Info("Switched to new branch", branch)
+	return nil
+}
+
+// branchAnswers helper struct for branch name prompts
+type branchAnswers struct {
+	Type        string `survey:"type"`
+	Ticket      string `survey:"ticket"`
+	Description string `survey:"description"`
+}
+
+// askBranchData prompt type, scope, ticket and description of branch
+func askBranchData() (BranchData, error) {
+	var ans branchAnswers
+	typeOptions := TypeOptions()
+	if err := survey.Ask([]*survey.Question{{
+		Name: "type",
+		Prompt: &survey.Select{
+			Message: "Choose branch type:",
+			Options: typeOptions,
+			Default: DefaultType(typeOptions),
+		},
+	}}, &ans, askOpts()...); err != nil {
+		return BranchData{}, err
+	}
+
+	var cs CommitScopes
+	if viper.GetBool("gitwok.commit.prompt.scope") {
+		if err := survey.Ask([]*survey.Question{scopeQuestion(ScopeOptions(), []string{})}, &cs, askOpts()...); err != nil {
+			return BranchData{}, err
+		}
+	}
+
+	if err := survey.Ask([]*survey.Question{
+		{
+			Name:      "ticket",
+			Prompt:    &survey.Input{Message: "Enter optional ticket id:"},
+			Transform: survey.TransformString(strings.TrimSpace),
+		},
+		{
+			Name:      "description",
+			Prompt:    &survey.Input{Message: "Enter short branch description:"},
+			Validate:  survey.Required,
+			Transform: survey.TransformString(strings.TrimSpace),
+		},
+	}, &ans, askOpts()...); err != nil {
+		return BranchData{}, err
+	}
+
+	return NewBranchData(ans.Type, cs.Scope, ans.Ticket, ans.Description), nil
+}
+
+var branchCmd = &cobra.Command{
+	Use:   "branch",
+	Short: "create conventionally named branch",
+	Long:  "Pass no flag to prompt for type, scope, ticket and description of the branch, or build the branch name with flags",
+	Run: func(cmd *cobra.Command, args []string) {
+		var git = &Git{
+			verbose: false,
+			dryRun:  mustBool(cmd.Flags().GetBool("dry-run")),
+		}
+
+		// count local flags set explicitly, see commitCmd
+		flagCount := 0
+		cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+			if f.Changed {
+				flagCount++
+			}
+		})
+
+		var data BranchData
+		if flagCount > 0 {
+			data = NewBranchData(
+				mustStr(cmd.LocalFlags().GetString("type")),
+				mustStr(cmd.LocalFlags().GetString("scope")),
+				mustStr(cmd.LocalFlags().GetString("ticket")),
+				mustStr(cmd.LocalFlags().GetString("description")),
+			)
+			if data.Type == "" || data.Description == "" {
+				logger.Fatal("branch type and description are required")
+			}
+		} else {
+			var err error
+			data, err = askBranchData()
+			must(err)
+		}
+
+		branch := mustStr(RenderBranch(data))
+		if msg := CheckBranchName(branch); msg != "" {
+			logger.Warn(msg)
+		}
+		must(createBranch(git, branch))
+		if isJSONOutput() {
+			printJSON(BranchOutput{branch})
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(branchCmd)
+
+	branchCmd.Flags().StringP("type", "t", "", "required: branch type")
+	branchCmd.Flags().StringP("scope", "s", "", "optional: branch scope")
+	branchCmd.Flags().StringP("ticket", "i", "", "optional: ticket id")
+	branchCmd.Flags().StringP("description", "d", "", "required: short branch description")
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSlugify(t *testing.T) {
+	testcases := []struct {
+		s      string
+		maxLen int
+		slug   string
+	}{
+		{"Add login page", 0, "add-login-page"},
+		{"  fix: the `API` (v2) bug!! ", 0, "fix-the-api-v2-bug"},
+		{"add login page with remember me", 20, "add-login-page-with"},
+		{"supercalifragilistic", 10, "supercalif"},
+	}
+
+	for _, tc := range testcases {
+		if slug := Slugify(tc.s, tc.maxLen); slug != tc.slug {
+			t.Errorf("Slugify %q expected %q, got: %q", tc.s, tc.slug, slug)
+		}
+	}
+}
+
+func TestBranchName(t *testing.T) {
+	viper.Set("gitwok.branch.pattern", BranchPatternTmpl)
+	defer viper.Set("gitwok.branch.pattern", nil)
+
+	testcases := []struct {
+		cm     CommitMsg
+		branch string
+	}{
+		{CommitMsg{Type: "feat", Scope: "api", Description: "Add login"}, "feat/api-add-login"},
+		{CommitMsg{Type: "fix", Description: "handle nil config"}, "fix/handle-nil-config"},
+		{CommitMsg{Type: "Fix", Scope: "api,web", Description: "typo"}, "fix/api-web-typo"},
+	}
+
+	for _, tc := range testcases {
+		if branch, err := BranchName(&tc.cm); err != nil || branch != tc.branch {
+			t.Errorf("Expected branch %q, got: %q, %v", tc.branch, branch, err)
+		}
+	}
+}
+
+func TestRenderBranch(t *testing.T) {
+	viper.Set("gitwok.branch.pattern", BranchPatternTmpl)
+	defer viper.Set("gitwok.branch.pattern", nil)
+
+	data := NewBranchData("Feat", "API", " abc 12 ", "Auth token refresh!")
+	if branch, err := RenderBranch(data); err != nil || branch != "feat/abc-12-api-auth-token-refresh" {
+		t.Errorf("Expected default pattern branch, got: %q, %v", branch, err)
+	}
+
+	viper.Set("gitwok.branch.pattern", "{{.Ticket}}/{{.Description}}")
+	if branch, _ := RenderBranch(NewBranchData("feat", "", "ABC-12", "refresh")); branch != "ABC-12/refresh" {
+		t.Errorf("Expected custom pattern branch, got: %q", branch)
+	}
+
+	viper.Set("gitwok.branch.pattern", "{{.Unknown}}")
+	if _, err := RenderBranch(data); err == nil {
+		t.Error("invalid pattern should error")
+	}
+}
+
+func TestCheckBranchName(t *testing.T) {
+	viper.Set("gitwok.commit.type", []string{"feat", "fix"})
+	viper.Set("gitwok.branch.ignore", []string{"main", "dependabot/**"})
+	defer viper.Set("gitwok.commit.type", PresetCommitTypes)
+	defer viper.Set("gitwok.branch.ignore", nil)
+
+	testcases := []struct {
+		branch string
+		ok     bool
+	}{
+		{"feat/api-auth-token-refresh", true},
+		{"fix/ABC-12-crash", true},
+		{"main", true},
+		{"dependabot/npm/lodash", true},
+		{"", true},
+		{"chore/bump", false},
+		{"feat/", false},
+		{"my-branch", false},
+	}
+
+	for _, tc := range testcases {
+		if msg := CheckBranchName(tc.branch); (msg == "") != tc.ok {
+			t.Errorf("Branch %q expected ok %v, got: %q", tc.branch, tc.ok, msg)
+		}
+	}
+
+	viper.Set("gitwok.branch.match", `^[A-Z]+-\d+/`)
+	defer viper.Set("gitwok.branch.match", "")
+	if msg := CheckBranchName("ABC-1/login"); msg != "" {
+		t.Errorf("Expected branch matching configured pattern, got: %q", msg)
+	}
+}
+
+func TestPushBranchViolations(t *testing.T) {
+	viper.Set("gitwok.rules.branch-name", SeverityError)
+	viper.Set("gitwok.commit.type", []string{"feat"})
+	defer viper.Set("gitwok.rules.branch-name", nil)
+	defer viper.Set("gitwok.commit.type", PresetCommitTypes)
+
+	stdin := strings.Join([]string{
+		"refs/heads/feat/login 1111111111111111111111111111111111111111 refs/heads/feat/login " + ZeroSha,
+		"refs/heads/wip 2222222222222222222222222222222222222222 refs/heads/wip " + ZeroSha,
+		"refs/heads/old " + ZeroSha + " refs/heads/old 3333333333333333333333333333333333333333",
+		"refs/tags/v1 4444444444444444444444444444444444444444 refs/tags/v1 " + ZeroSha,
+	}, "\n")
+
+	updates := ParsePushUpdates(strings.NewReader(stdin))
+	if len(updates) != 4 || !updates[2].IsDelete() {
+		t.Fatalf("Expected 4 updates with a delete, got: %v", updates)
+	}
+
+	violations := pushBranchViolations(updates)
+	if len(violations) != 1 || !strings.Contains(violations[0].Message, `"wip"`) {
+		t.Errorf("Expected violation of wip branch only, got: %v", violations)
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/viper"
+)
+
+// FTokenCoAuthor co-author footer token
+const FTokenCoAuthor = "Co-authored-by"
+
+// IdentPattern matches `Name <email>`
+var IdentPattern = regexp.MustCompile(`^[^<>]+ <[^<>\s]+>$`)
+
+// ParseShortlog parse `git shortlog -sne` output into `Name <email>` list
+func ParseShortlog(out string) []string {
+	authors := []string{}
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		// line format: `  <count>\t<Name> <<email>>`
+		fields := strings.SplitN(strings.TrimSpace(scanner.Text()), "\t", 2)
+		if len(fields) == 2 && IdentPattern.MatchString(fields[1]) {
+			authors = append(authors, fields[1])
+		}
+	}
+	return authors
+}
+
+// Authors exec `git shortlog -sne HEAD` and return authors of
+// repository history by commit count, empty if no commit yet
+func (git *Git) Authors() []string {
+	out, err := git.run("shortlog", "-sne", "HEAD")
+	if err != nil {
+		logger.Verbose(err)
+		return []string{}
+	}
+	return ParseShortlog(out)
+}
+
+// UserIdent `Name <email>` of git config user.name and user.email
+func (git *Git) UserIdent() string {
+	name, _ := git.run("config", "user.name")
+	email, _ := git.run("config", "user.email")
+	return fmt.Sprintf("%s <%s>", name, email)
+}
+
+// CoAuthorOptions configured roster followed by repository authors,
+// deduplicated by email, excluding the current user
+func CoAuthorOptions(roster, authors []string, self string) []string {
+	emailOf := func(ident string) string {
+		if i := strings.LastIndex(ident, "<"); i >= 0 {
+			return strings.ToLower(ident[i:])
+		}
+		return strings.ToLower(ident)
+	}
+
+	seen := map[string]bool{emailOf(self): true}
+	options := []string{}
+	for _, ident := range append(append([]string{}, roster...), authors...) {
+		if email := emailOf(ident); !seen[email] {
+			seen[email] = true
+			options = append(options, ident)
+		}
+	}
+	return options
+}
+
+// FuzzyMatch case insensitive match of filter as a subsequence of value,
+// spaces in filter are ignored
+func FuzzyMatch(filter, value string, index int) bool {
+	value = strings.ToLower(value)
+	i := 0
+	for _, c := range strings.ToLower(filter) {
+		if unicode.IsSpace(c) {
+			continue
+		}
+		j := strings.IndexRune(value[i:], c)
+		if j < 0 {
+			return false
+		}
+		i += j + len(string(c))
+	}
+	return true
+}
+
+// ResolveCoAuthor return value if in `Name <email>` format, otherwise
+// the first option fuzzy matching value, "" if none
+func ResolveCoAuthor(value string, options []string) string {
+	value = strings.TrimSpace(value)
+	if IdentPattern.MatchString(value) {
+		return value
+	}
+	for i, opt := range options {
+		if FuzzyMatch(value, opt, i) {
+			return opt
+		}
+	}
+	return ""
+}
+
+// coAuthorOptions co-author options of gitwok.commit.coauthors and repository history
+func coAuthorOptions(git *Git) []string {
+	return CoAuthorOptions(viper.GetStringSlice("gitwok.commit.coauthors"), git.Authors(), git.UserIdent())
+}
+
+// AddCoAuthors append co-author footers not added yet
+func (cm *CommitMsg) AddCoAuthors(idents []string) {
+	for _, ident := range idents {
+		if footer := MakeFooter(FTokenCoAuthor, ident); !containsStr(cm.Footers, footer) {
+			cm.Footers = append(cm.Footers, footer)
+		}
+	}
+}
+
+// askCoAuthors multi select co-authors with fuzzy filtering
+func askCoAuthors(git *Git) ([]string, error) {
+	idents := []string{}
+	options := coAuthorOptions(git)
+	if len(options) == 0 {
+		return idents, nil
+	}
+
+	err := survey.AskOne(&survey.MultiSelect{
+		Message: "Choose co-authors:",
+		Options: options,
+		Filter:  FuzzyMatch,
+	}, &idents, askOpts()...)
+
+	return idents, err
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import "testing"
+
+func TestParseShortlog(t *testing.T) {
+	out := "    12\tJane Doe <jane@example.com>\n     3\tJohn <john@example.com>\n     1\tbroken line\n"
+	expected := []string{"Jane Doe <jane@example.com>", "John <john@example.com>"}
+	if got := ParseShortlog(out); !CompareStrSlices(got, expected) {
+		t.Errorf("ParseShortlog failed, expected: %v, got: %v", expected, got)
+	}
+}
+
+func TestCoAuthorOptions(t *testing.T) {
+	roster := []string{"Jane Doe <jane@example.com>"}
+	authors := []string{"Me <me@example.com>", "Jane <JANE@example.com>", "John <john@example.com>"}
+	expected := []string{"Jane Doe <jane@example.com>", "John <john@example.com>"}
+	if got := CoAuthorOptions(roster, authors, "Me <me@example.com>"); !CompareStrSlices(got, expected) {
+		t.Errorf("CoAuthorOptions failed, expected: %v, got: %v", expected, got)
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	var tests = []TestBool{
+		{FuzzyMatch("", "Jane Doe <jane@example.com>", 0), true, "empty filter"},
+		{FuzzyMatch("jd", "Jane Doe <jane@example.com>", 0), true, "initials"},
+		{FuzzyMatch("JANE EX", "Jane Doe <jane@example.com>", 0), true, "case and spaces"},
+		{FuzzyMatch("dj", "Jane Doe <jane@example.com>", 0), true, "subsequence in email"},
+		{FuzzyMatch("xyz", "Jane Doe <jane@example.com>", 0), false, "no match"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("FuzzyMatch with %s failed, expected: %t, got: %t", test.msg, test.expected, test.got)
+		}
+	}
+}
+
+func TestResolveCoAuthor(t *testing.T) {
+	options := []string{"Jane Doe <jane@example.com>", "John <john@example.com>"}
+	var tests = []TestStr{
+		{ResolveCoAuthor("Someone <someone@example.com>", options), "Someone <someone@example.com>", "ident"},
+		{ResolveCoAuthor("Doe, Jane <j@example.com>", options), "Doe, Jane <j@example.com>", "ident with comma"},
+		{ResolveCoAuthor("john", options), "John <john@example.com>", "name"},
+		{ResolveCoAuthor("nobody", options), "", "no match"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("ResolveCoAuthor with %s failed, expected: %q, got: %q", test.msg, test.expected, test.got)
+		}
+	}
+}
+
+func TestAddCoAuthors(t *testing.T) {
+	cm := makeCommitMsg("feat", "", false, "pair", "", []string{"Co-authored-by: John <john@example.com>"})
+	cm.AddCoAuthors([]string{"Jane Doe <jane@example.com>", "John <john@example.com>"})
+
+	expected := []string{"Co-authored-by: John <john@example.com>", "Co-authored-by: Jane Doe <jane@example.com>"}
+	if !CompareStrSlices(cm.Footers, expected) {
+		t.Errorf("AddCoAuthors failed, expected: %v, got: %v", expected, cm.Footers)
+	}
+	if ok, msg := cm.Validate(); !ok {
+		t.Errorf("AddCoAuthors failed, expected valid footers, got: %q", msg)
+	}
+}
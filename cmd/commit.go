@@ -2,13 +2,16 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 	"text/template"
 	"unicode"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -42,16 +45,32 @@ const (
 	FSepColonSpace = ": "
 	// FSepSpaceSharp footer separator
 	FSepSpaceSharp = " #"
+
+	// UnknownType error msg of type not in configured options
+	UnknownType = "commit type is not in configured options"
+	// UnknownScope error msg of scope not in configured options
+	UnknownScope = "commit scope is not in configured options"
+
+	// EnforceStrict reject type or scope not in configured options
+	EnforceStrict = "strict"
+	// EnforceWarn warn about type or scope not in configured options
+	EnforceWarn = "warn"
+	// EnforceOff skip checking type and scope against configured options
+	EnforceOff = "off"
 )
 
 // CommitMsg properties
 type CommitMsg struct {
-	Type         string   `survey:"type"`        // required, preset or config values only
-	Scope        string   `survey:"scope"`       // optional
-	HasBrkChange bool     `survey:"breaking"`    // optional, default false
-	Description  string   `survey:"description"` // required, no line break
-	Body         string   `survey:"body"`        // optional, allow line breaks
-	Footers      []string `survey:"footers"`     // optional, allow multiple lines
+	Type         string   `survey:"type" json:"type"`               // required, preset or config values only
+	Scope        string   `survey:"scope" json:"scope"`             // optional
+	HasBrkChange bool     `survey:"breaking" json:"breaking"`       // optional, default false
+	Description  string   `survey:"description" json:"description"` // required, no line break
+	Body         string   `survey:"body" json:"body"`               // optional, allow line breaks
+	Footers      []string `survey:"footers" json:"footers"`         // optional, allow multiple lines
+	raw          string   // raw message if parsed, for rules checking line layout
+	emoji        string   // emoji stripped from header if parsed
+	author       string   // `Name <email>` of commit author if linted from history
+	branch       string   // branch pushed if linted from history, to match tickets of
 }
 
 // CommitMsgTmpl template for building commit message
@@ -64,6 +83,17 @@ const CommitMsgTmpl = `{{.Type}}{{if .Scope}}({{.Scope}}){{end}}{{if .HasBrkChan
 {{end}}
 {{end}}`
 
+// stdio of interactive prompts, default os.Stdin, os.Stdout and os.Stderr if nil
+var stdio *terminal.Stdio
+
+// askOpts survey ask options with prompt stdio
+func askOpts(opts ...survey.AskOpt) []survey.AskOpt {
+	if stdio != nil {
+		opts = append(opts, survey.WithStdio(stdio.In, stdio.Out, stdio.Err))
+	}
+	return opts
+}
+
 // PresetCommitTypes conventional commits suggested types
 var PresetCommitTypes = []string{"fix", "feat", "build", "chore", "ci", "docs", "perf", "refactor", "style", "test"}
 
@@ -87,6 +117,10 @@ var cmtBrkConfirm = &survey.Question{
 	},
 }
 
+var cmtBrkDescInput = &survey.Input{
+	Message: "Describe the breaking changes:",
+}
+
 var cmtDescInput = &survey.Question{
 	Name: "description",
 	Prompt: &survey.Input{
@@ -179,6 +213,16 @@ func IsBrkChnFooter(token string) bool {
 	return token == FTokenBrkChange || token == FTokenBrkChangeAlias
 }
 
+// HasBrkChnFooter check if commit msg has a breaking change footer
+func (cm *CommitMsg) HasBrkChnFooter() bool {
+	for _, f := range cm.Footers {
+		if token, _, _ := ParseFooter(f); IsBrkChnFooter(token) {
+			return true
+		}
+	}
+	return false
+}
+
 // MatchFooters takes raw footers input string and return
 // string slice of found footers.
 // Match <token + sep>, find indices and take values in between
@@ -214,15 +258,16 @@ func MatchFooters(str string) []string {
 }
 
 // ParseFooter return components of a commit msg footer if seperable by ": " or " #"
+// split at the first separator only, value may contain separators
 // @param f footer without no newlines
 // @return token "" if separated wrongly
 // @return sep "" if separated wrongly
 // @return val "" if separated wrongly
 func ParseFooter(f string) (token, sep, val string) {
-	if elms := strings.Split(f, FSepColonSpace); len(elms) == 2 {
+	if elms := strings.SplitN(f, FSepColonSpace, 2); len(elms) == 2 {
 		token, sep, val = elms[0], FSepColonSpace, elms[1]
 		return
-	} else if elms := strings.Split(f, FSepSpaceSharp); len(elms) == 2 {
+	} else if elms := strings.SplitN(f, FSepSpaceSharp, 2); len(elms) == 2 {
 		token, sep, val = elms[0], FSepSpaceSharp, elms[1]
 		return
 	}
@@ -254,43 +299,118 @@ func TrimFooter(s string) string {
 
 // Validate commit msg elements
 // @return valid {bool}
-// @return msg {string} error msg
+// @return msg {string} first error msg
 func (cm *CommitMsg) Validate() (bool, string) {
+	if errs := cm.specErrors(); len(errs) > 0 {
+		return false, errs[0]
+	}
+	return true, ""
+}
+
+// specErrors check commit msg elements against the spec
+// @return errs {[]string} all error msgs
+func (cm *CommitMsg) specErrors() []string {
+	errs := []string{}
 	if cm.Type == "" {
-		return false, RequiredType
+		errs = append(errs, RequiredType)
 	} else if ContainsWhiteSpace(cm.Type) {
-		return false, InvalidType
+		errs = append(errs, InvalidType)
 	}
 
 	if cm.Scope != "" && ContainsNewline(cm.Scope) {
-		return false, InvalidScope
+		errs = append(errs, InvalidScope)
 	}
 
 	if cm.Description == "" {
-		return false, RequiredDesc
+		errs = append(errs, RequiredDesc)
 	} else if ContainsNewline(cm.Description) {
-		return false, InvalidDesc
+		errs = append(errs, InvalidDesc)
 	}
 
-	if len(cm.Footers) > 0 {
-		for _, f := range cm.Footers {
-			token, sep, val := ParseFooter(f)
-			if token == "" || sep == "" {
-				return false, InvalidFooter
-			}
-			if token != FTokenBrkChange && ContainsWhiteSpace(token) {
-				return false, InvalidFooterToken
-			}
-			if IsBrkChnFooter(token) && sep != FSepColonSpace {
-				return false, InvalidBrkChnFTSep
-			}
-			if IsBrkChnFooter(token) && val == "" {
-				return false, RequiredBrkChnFTDesc
+	for _, f := range cm.Footers {
+		token, sep, val := ParseFooter(f)
+		if token == "" || sep == "" {
+			errs = append(errs, InvalidFooter)
+			continue
+		}
+		if token != FTokenBrkChange && ContainsWhiteSpace(token) {
+			errs = append(errs, InvalidFooterToken)
+		}
+		if IsBrkChnFooter(token) && sep != FSepColonSpace {
+			errs = append(errs, InvalidBrkChnFTSep)
+		}
+		if IsBrkChnFooter(token) && val == "" {
+			errs = append(errs, RequiredBrkChnFTDesc)
+		}
+	}
+
+	return errs
+}
+
+// Header first line of the formatted commit msg
+func (cm *CommitMsg) Header() string {
+	return strings.SplitN(cm.ToString(), "\n", 2)[0]
+}
+
+func enumMsg(errMsg, val string, options []string) string {
+	msg := fmt.Sprintf("%s: %q", errMsg, val)
+	if suggestion := Suggest(val, options); suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", suggestion)
+	}
+	return msg
+}
+
+// Suggest return the option closest to val by edit distance,
+// or "" if none is close enough to be a likely typo
+func Suggest(val string, options []string) string {
+	suggestion, min := "", len([]rune(val))/2+2
+	for _, opt := range options {
+		if d := EditDistance(strings.ToLower(val), strings.ToLower(opt)); d < min {
+			suggestion, min = opt, d
+		}
+	}
+	return suggestion
+}
+
+// EditDistance levenshtein distance between two strings
+func EditDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
 			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
 		}
+		prev, curr = curr, prev
 	}
+	return prev[len(t)]
+}
 
-	return true, ""
+func minInt(first int, rest ...int) int {
+	min := first
+	for _, v := range rest {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+func containsStr(s []string, val string) bool {
+	for _, v := range s {
+		if v == val {
+			return true
+		}
+	}
+	return false
 }
 
 // ToString format commit msg as conventional commits spec v1.0.0
@@ -303,56 +423,111 @@ func (cm *CommitMsg) ToString() string {
 	return tmplBytes.String()
 }
 
-// Commit validate and git commit the CommitMsg
-func (cm *CommitMsg) Commit(git *Git) {
-	if ok, msg := cm.Validate(); ok {
-		cmtMsgStr := cm.ToString()
-		logger.Verbose(fmt.Sprintln("Executing git commit -m with msg: ") + cmtMsgStr)
+// ErrInvalidMsg commit msg has lint errors
+var ErrInvalidMsg = errors.New("commit message is invalid")
 
-		git.Commit("-m", cmtMsgStr)
-	} else {
-		logger.Fatal(msg)
+// Check render and lint the CommitMsg, exit if invalid
+// @return msg {string} rendered commit msg
+func (cm *CommitMsg) Check(git *Git) string {
+	cmtMsgStr, err := cm.Render(git)
+	if err != nil {
+		logger.Error(err)
+		exitCommit([]Violation{}, err)
 	}
+
+	violations := LintMsg(cmtMsgStr)
+	if ok := logViolations(violations); !ok {
+		exitCommit(violations, ErrInvalidMsg)
+	}
+	return cmtMsgStr
 }
 
-// Prompt use interactive prompts to build the commit message
-func (cm *CommitMsg) Prompt() {
-	var questions = []*survey.Question{}
+// Commit git commit the msg checked by Check
+func (cm *CommitMsg) Commit(git *Git, cmtMsgStr string) {
+	logger.Verbose(fmt.Sprintln("Executing git commit -m with msg: ") + cmtMsgStr)
 
-	// prompt type
-	var typeOptions []string
+	if err := git.Commit(append([]string{"-m", cmtMsgStr}, SignArgs()...)...); err != nil {
+		logger.Error(err)
+		exitCommit(LintMsg(cmtMsgStr), err)
+	}
+	if isJSONOutput() {
+		var sha string
+		if !git.dryRun {
+			sha = mustStr(git.run("rev-parse", "HEAD"))
+		}
+		parsed, _ := ParseCommitMsg(cmtMsgStr)
+		printJSON(CommitOutput{sha, cmtMsgStr, parsed})
+	}
+}
+
+// exitCommit exit 1, print why commit failed as LintOutput in json output mode
+func exitCommit(violations []Violation, err error) {
+	if isJSONOutput() {
+		printJSON(LintOutput{false, violations, err.Error()})
+	}
+	os.Exit(1)
+}
+
+// Complete mark `!` if breaking change footer given, fill in
+// tickets of branch and sign-off by config
+func (cm *CommitMsg) Complete(git *Git) {
+	if viper.GetBool("gitwok.commit.breaking.footer") && cm.HasBrkChnFooter() {
+		cm.HasBrkChange = true
+	}
+	cm.FillTickets(BranchTickets(git))
+	if viper.GetBool("gitwok.commit.signoff") {
+		cm.SignOff(git.UserIdent())
+	}
+}
+
+// TypeOptions commit types of config, preset types if none
+func TypeOptions() []string {
 	if options := viper.GetStringSlice("gitwok.commit.type"); len(options) != 0 {
-		typeOptions = options
-	} else {
-		typeOptions = PresetCommitTypes
+		return options
 	}
+	return PresetCommitTypes
+}
 
+// Prompt use interactive prompts to build the commit message, exit on error
+func (cm *CommitMsg) Prompt() {
+	must(cm.Ask())
+}
+
+// Ask use interactive prompts to build the commit message
+// @return err {error} i.e. terminal.InterruptErr if prompt interrupted
+func (cm *CommitMsg) Ask() error {
+	if viper.GetString("gitwok.commit.editor.mode") == EditorModeMessage {
+		return cm.askMsgInEditor()
+	}
+
+	var questions = []*survey.Question{}
+
+	// prompt type
+	typeOptions := TypeOptions()
 	questions = append(questions, &survey.Question{
 		Name: "type",
 		Prompt: &survey.Select{
 			Message: "Choose commit type:",
 			Options: typeOptions,
-			Default: typeOptions[0],
+			Default: DefaultType(typeOptions),
 		},
 	})
 
+	if err := survey.Ask(questions, cm, askOpts()...); err != nil {
+		return err
+	}
+
 	// prompt scope
 	if prompt := viper.GetBool("gitwok.commit.prompt.scope"); prompt {
-		if options := viper.GetStringSlice("gitwok.commit.scope"); len(options) != 0 {
-			var cmtScopeSelect = &survey.Question{
-				Name: "scope",
-				Prompt: &survey.Select{
-					Message: "Choose commit scope:",
-					Options: options,
-					Default: options[0],
-				},
-			}
-			questions = append(questions, cmtScopeSelect)
-		} else {
-			questions = append(questions, cmtScopeInput)
+		var cs CommitScopes
+		if err := survey.Ask([]*survey.Question{scopeQuestion(ScopeOptions(), suggestScopes(&Git{}))}, &cs, askOpts()...); err != nil {
+			return err
 		}
+		cm.Scope = cs.Scope
 	}
 
+	questions = []*survey.Question{}
+
 	// prompt breaking
 	if prompt := viper.GetBool("gitwok.commit.prompt.breaking"); prompt {
 		questions = append(questions, cmtBrkConfirm)
@@ -363,17 +538,57 @@ func (cm *CommitMsg) Prompt() {
 
 	// prompt body
 	if prompt := viper.GetBool("gitwok.commit.prompt.body"); prompt {
-		questions = append(questions, cmtBodyMulti)
+		questions = append(questions, bodyQuestion())
 	}
 
-	must(survey.Ask(questions, cm))
+	if err := survey.Ask(questions, cm, askOpts()...); err != nil {
+		return err
+	}
 
 	// prompt footers
 	if prompt := viper.GetBool("gitwok.commit.prompt.footers"); prompt {
-		var ft CommitFooters
-		must(survey.Ask(FootersQuestions, &ft))
-		cm.Footers = ft.Footers
+		if viper.GetString("gitwok.commit.footer.prompt") == FooterPromptMultiline {
+			var ft CommitFooters
+			if err := survey.Ask(FootersQuestions, &ft, askOpts()...); err != nil {
+				return err
+			}
+			cm.Footers = ft.Footers
+		} else {
+			footers, err := askFooters()
+			if err != nil {
+				return err
+			}
+			cm.Footers = footers
+		}
+	}
+
+	// prompt breaking change footer
+	if cm.HasBrkChange && !cm.HasBrkChnFooter() && viper.GetBool("gitwok.commit.breaking.footer") {
+		var desc string
+		if err := survey.AskOne(cmtBrkDescInput, &desc, askOpts(survey.WithValidator(survey.Required))...); err != nil {
+			return err
+		}
+		cm.Footers = append(cm.Footers, MakeFooter(FTokenBrkChange, desc))
+	}
+
+	// prompt co-authors
+	if prompt := viper.GetBool("gitwok.commit.prompt.coauthors"); prompt {
+		idents, err := askCoAuthors(&Git{})
+		if err != nil {
+			return err
+		}
+		cm.AddCoAuthors(idents)
 	}
+
+	return nil
+}
+
+// signFlagKeys config keys of commit signing flags, applicable in both modes
+var signFlagKeys = map[string]string{
+	"signoff":     "gitwok.commit.signoff",
+	"sign":        "gitwok.commit.sign.enabled",
+	"sign-key":    "gitwok.commit.sign.key",
+	"sign-format": "gitwok.commit.sign.format",
 }
 
 // commitCmd represents the commit command
@@ -382,20 +597,44 @@ var commitCmd = &cobra.Command{
 	Short: "build and make conventional commit",
 	Long:  "Pass no flag to use interactive mode or build commit message with flags",
 	Run: func(cmd *cobra.Command, args []string) {
+		applyOverrides(&Git{})
+
+		// signing flags override config
+		for name, key := range signFlagKeys {
+			if f := cmd.LocalFlags().Lookup(name); f.Changed {
+				viper.Set(key, f.Value.String())
+			}
+		}
+		if cmd.LocalFlags().Changed("sign-key") {
+			viper.Set("gitwok.commit.sign.enabled", true)
+		}
+
 		var git = &Git{
 			verbose: false,
 			dryRun:  mustBool(cmd.Flags().GetBool("dry-run")),
+			configs: SignConfigs(),
 		}
 
-		// count local flags set explicitly
+		// count local flags of commit msg set explicitly
 		// cobra issue: https://github.com/spf13/cobra/issues/1315
 		flagCount := 0
 		cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
-			if f.Changed {
+			// sign and co-author flags apply to both modes
+			if _, isSignFlag := signFlagKeys[f.Name]; f.Changed && !isSignFlag && f.Name != "co-author" {
 				flagCount++
 			}
 		})
 
+		cmtCoAuthors := mustStrSlice(cmd.LocalFlags().GetStringArray("co-author"))
+		if len(cmtCoAuthors) > 0 {
+			options := coAuthorOptions(git)
+			for i, value := range cmtCoAuthors {
+				if cmtCoAuthors[i] = ResolveCoAuthor(value, options); cmtCoAuthors[i] == "" {
+					logger.Fatal(fmt.Sprintf("No co-author matching %q", value))
+				}
+			}
+		}
+
 		// use flags mode if any flag has been set
 		if flagCount > 0 {
 			// readonly local flags
@@ -406,13 +645,29 @@ var commitCmd = &cobra.Command{
 			cmtBody := mustStr(cmd.LocalFlags().GetString("body"))
 			cmtFooters := mustStrSlice(cmd.LocalFlags().GetStringSlice("footers"))
 
+			// fill in default type and scopes of staged files if omitted
+			if !cmd.LocalFlags().Changed("type") {
+				cmtType = viper.GetString("gitwok.commit.defaults.type")
+			}
+			if !cmd.LocalFlags().Changed("scope") {
+				cmtScope = JoinScopes(suggestScopes(git))
+			}
+
 			// try construct commit msg from flags
 			cmtMsg := makeCommitMsg(cmtType, cmtScope, cmtHasBrkChange, cmtDescription, cmtBody, cmtFooters)
-			cmtMsg.Commit(git)
+			cmtMsg.AddCoAuthors(cmtCoAuthors)
+			cmtMsg.Complete(git)
+			msg := cmtMsg.Check(git)
+			must(cmtMsg.Guard(git))
+			cmtMsg.Commit(git, msg)
 		} else {
 			var cmtMsg CommitMsg
 			cmtMsg.Prompt()
-			cmtMsg.Commit(git)
+			cmtMsg.AddCoAuthors(cmtCoAuthors)
+			cmtMsg.Complete(git)
+			msg := cmtMsg.Check(git)
+			must(cmtMsg.Guard(git))
+			cmtMsg.Commit(git, msg)
 		}
 	},
 }
@@ -426,6 +681,18 @@ func init() {
 	commitCmd.Flags().StringP("description", "d", "", "required: commit description")
 	commitCmd.Flags().StringP("body", "b", "", "optional: commit body")
 	commitCmd.Flags().StringSliceP("footers", "f", []string{}, "optional: commit footers, allow multiple")
+	commitCmd.Flags().StringArray("co-author", []string{}, "optional: co-author \"Name <email>\" or name to match, allow multiple, also in interactive mode")
+	commitCmd.Flags().Bool("signoff", false, "add Signed-off-by footer of git user.name and user.email")
+	commitCmd.Flags().Bool("sign", false, "sign commit with GPG or SSH key")
+	commitCmd.Flags().String("sign-key", "", "key id to sign commit, implies --sign")
+	commitCmd.Flags().String("sign-format", "", "signature format: openpgp | ssh | x509")
+}
+
+// newTmpl new template with helper funcs
+func newTmpl(name string) *template.Template {
+	return template.New(name).Funcs(template.FuncMap{
+		"join": strings.Join,
+	})
 }
 
 func mustTmpl(tmpl *template.Template, err error) *template.Template {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 func TestContainsNewline(t *testing.T) {
@@ -264,3 +266,61 @@ func TestCommitCmdRun(t *testing.T) {
 	// }
 	// t.Fatalf("TestCommitCmdRun ran with err %v, want exit status 1", err)
 }
+
+func TestEditDistance(t *testing.T) {
+	var tests = []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"fix", "", 3},
+		{"feat", "feat", 0},
+		{"feture", "feature", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, test := range tests {
+		if got := EditDistance(test.a, test.b); got != test.expected {
+			t.Errorf("EditDistance(%q, %q) failed, expected: %d, got: %d", test.a, test.b, test.expected, got)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	var tests = []TestStr{
+		{Suggest("feture", PresetCommitTypes), "feat", "typo of feat"},
+		{Suggest("Fix", PresetCommitTypes), "fix", "case of fix"},
+		{Suggest("docz", PresetCommitTypes), "docs", "typo of docs"},
+		{Suggest("nonsense", PresetCommitTypes), "", "no close option"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("Suggest with %s failed, expected: %q, got: %q", test.msg, test.expected, test.got)
+		}
+	}
+}
+
+func TestParseFooterSeparatorInValue(t *testing.T) {
+	if token, sep, val := ParseFooter("Refs: note: see #1"); token != "Refs" || sep != FSepColonSpace || val != "note: see #1" {
+		t.Errorf("ParseFooter check failed, got: %q, %q, %q", token, sep, val)
+	}
+}
+
+func TestCommitMsgComplete(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	cm := makeCommitMsg("feat", "", false, "desc", "", []string{"BREAKING CHANGE: removed flag"})
+	cm.Complete(&Git{})
+	if cm.HasBrkChange {
+		t.Error("Complete failed, expected no breaking change marked by default")
+	}
+
+	viper.Set("gitwok.commit.breaking.footer", true)
+	cm.Complete(&Git{})
+	if !cm.HasBrkChange {
+		t.Error("Complete failed, expected breaking change marked by footer")
+	}
+}
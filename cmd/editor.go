@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/viper"
+)
+
+const (
+	// EditorModeOff prompt body by multiline input
+	EditorModeOff = "off"
+	// EditorModeBody write body in editor
+	EditorModeBody = "body"
+	// EditorModeMessage write the whole message in editor
+	EditorModeMessage = "message"
+)
+
+// BodyEditorTmpl commented template of body written in editor
+const BodyEditorTmpl = `
+# Please enter the commit body. Lines starting with '#' will be ignored.
+# Paragraphs are wrapped at {{.Wrap}} columns, lists and code blocks are kept.
+`
+
+// MsgEditorTmpl commented template of whole message written in editor
+const MsgEditorTmpl = `
+# Please enter the commit message as:
+#
+# <type>[(scope)][!]: <description>
+#
+# [body]
+#
+# [footers]
+#
+# Types: {{join .Types ", "}}
+{{- if .Scopes}}
+# Scopes: {{join .Scopes ", "}}
+{{- end}}
+# Lines starting with '#' will be ignored.
+# Body paragraphs are wrapped at {{.Wrap}} columns, lists and code blocks are kept.
+`
+
+var listItemPattern = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+`)
+
+// EditorCmd editor git would use by `git var GIT_EDITOR`, respecting
+// $GIT_EDITOR, core.editor, $VISUAL and $EDITOR in order
+func (git *Git) EditorCmd() string {
+	editor, err := git.run("var", "GIT_EDITOR")
+	if err != nil {
+		return ""
+	}
+	return editor
+}
+
+// footerStart index of the first line of the trailing footer block, paragraphs
+// starting with a footer line, or trailers ending the last paragraph as parsed
+// by ParseCommitMsg, len(lines) if none
+func footerStart(lines []string) int {
+	start := len(lines)
+	end := len(lines) - 1
+	for {
+		for end >= 0 && strings.TrimSpace(lines[end]) == "" {
+			end--
+		}
+		if end < 0 {
+			return start
+		}
+		first := end
+		for first > 0 && strings.TrimSpace(lines[first-1]) != "" {
+			first--
+		}
+
+		if !FooterPattern.MatchString(lines[first]) {
+			if start == len(lines) {
+				for k := end; k > first && IsTrailer(lines[k]); k-- {
+					start = k
+				}
+			}
+			return start
+		}
+		start, end = first, first-1
+	}
+}
+
+// Reflow wrap paragraphs of text at width, list items are wrapped with
+// hanging indent, fenced or indented code blocks and the trailing footer
+// block are kept, width of 0 or less disables wrapping
+func Reflow(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+
+	out := []string{}
+	words := []string{}
+	indent := ""
+	inFence := false
+
+	flush := func() {
+		if len(words) > 0 {
+			out = append(out, wrapWords(words, width, indent)...)
+			words = []string{}
+		}
+	}
+
+	lines := strings.Split(text, "\n")
+	ftStart := footerStart(lines)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			inFence = !inFence
+			out = append(out, line)
+		case inFence, strings.HasPrefix(line, "    "), strings.HasPrefix(line, "\t"), i >= ftStart:
+			flush()
+			out = append(out, line)
+		case trimmed == "":
+			flush()
+			out = append(out, "")
+		case listItemPattern.MatchString(line):
+			flush()
+			marker := listItemPattern.FindString(line)
+			indent = strings.Repeat(" ", len(marker))
+			words = append([]string{strings.TrimRight(marker, " ") + " " + firstWord(line[len(marker):])}, restWords(line[len(marker):])...)
+		default:
+			if len(words) == 0 {
+				indent = ""
+			}
+			words = append(words, strings.Fields(line)...)
+		}
+	}
+	flush()
+
+	return strings.Join(out, "\n")
+}
+
+func firstWord(s string) string {
+	if fields := strings.Fields(s); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+func restWords(s string) []string {
+	if fields := strings.Fields(s); len(fields) > 1 {
+		return fields[1:]
+	}
+	return []string{}
+}
+
+// wrapWords greedy wrap words into lines of width, continuation lines
+// are prefixed by indent, words longer than width are not broken
+func wrapWords(words []string, width int, indent string) []string {
+	lines := []string{}
+	line := words[0]
+	for _, w := range words[1:] {
+		if len([]rune(line))+1+len([]rune(w)) > width {
+			lines = append(lines, line)
+			line = indent + w
+		} else {
+			line += " " + w
+		}
+	}
+	return append(lines, line)
+}
+
+// editorText strip comments of editor answer and reflow at configured width
+func editorText(ans string) string {
+	return Reflow(StripComments(ans), viper.GetInt("gitwok.commit.editor.wrap"))
+}
+
+// editorTmpl execute editor template with config data
+func editorTmpl(text string) string {
+	var sb strings.Builder
+	tmpl := mustTmpl(newTmpl("editor").Parse(text))
+	must(tmpl.Execute(&sb, map[string]interface{}{
+		"Wrap":   viper.GetInt("gitwok.commit.editor.wrap"),
+		"Types":  viper.GetStringSlice("gitwok.commit.type"),
+		"Scopes": ScopeOptions(),
+	}))
+	return sb.String()
+}
+
+// bodyQuestion editor if enabled by gitwok.commit.editor.mode, otherwise multiline
+func bodyQuestion() *survey.Question {
+	if viper.GetString("gitwok.commit.editor.mode") != EditorModeBody {
+		return cmtBodyMulti
+	}
+
+	return &survey.Question{
+		Name: "body",
+		Prompt: &survey.Editor{
+			Message:       "Enter optional commit body:",
+			Editor:        (&Git{}).EditorCmd(),
+			Default:       editorTmpl(BodyEditorTmpl),
+			HideDefault:   true,
+			AppendDefault: true,
+			FileName:      "COMMIT_BODY*.txt",
+		},
+		Transform: func(ans interface{}) interface{} {
+			s, _ := ans.(string)
+			return editorText(s)
+		},
+	}
+}
+
+// askMsgInEditor write the whole commit message in editor
+func (cm *CommitMsg) askMsgInEditor() error {
+	var text string
+	if err := survey.AskOne(&survey.Editor{
+		Message:       "Enter commit message:",
+		Editor:        (&Git{}).EditorCmd(),
+		Default:       editorTmpl(MsgEditorTmpl),
+		HideDefault:   true,
+		AppendDefault: true,
+		FileName:      "COMMIT_EDITMSG*.txt",
+	}, &text, askOpts()...); err != nil {
+		return err
+	}
+
+	parsed, ok := ParseCommitMsg(StripComments(text))
+	if !ok {
+		return errors.New(InvalidHeader)
+	}
+	parsed.Body = Reflow(parsed.Body, viper.GetInt("gitwok.commit.editor.wrap"))
+	parsed.raw = ""
+	*cm = *parsed
+
+	logger.Verbose(fmt.Sprintln("Message from editor:") + cm.ToString())
+	return nil
+}
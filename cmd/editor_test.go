@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/viper"
+)
+
+func TestReflow(t *testing.T) {
+	text := strings.Join([]string{
+		"This paragraph is long enough to be wrapped at twenty columns.",
+		"",
+		"- a list item that also needs wrapping",
+		"  with continuation",
+		"- short item",
+		"1. numbered item wrapped too",
+		"",
+		"```",
+		"code line that is not wrapped at all",
+		"```",
+		"    indented code that is kept as is",
+		"",
+		"Refs: a footer line that is kept as is",
+	}, "\n")
+
+	expected := strings.Join([]string{
+		"This paragraph is",
+		"long enough to be",
+		"wrapped at twenty",
+		"columns.",
+		"",
+		"- a list item that",
+		"  also needs",
+		"  wrapping with",
+		"  continuation",
+		"- short item",
+		"1. numbered item",
+		"   wrapped too",
+		"",
+		"```",
+		"code line that is not wrapped at all",
+		"```",
+		"    indented code that is kept as is",
+		"",
+		"Refs: a footer line that is kept as is",
+	}, "\n")
+
+	if got := Reflow(text, 20); got != expected {
+		t.Errorf("Reflow failed, expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if got := Reflow(text, 0); got != text {
+		t.Errorf("Reflow with 0 width failed, expected text kept, got:\n%s", got)
+	}
+
+	if got, expected := Reflow("averyveryverylongword short", 5), "averyveryverylongword\nshort"; got != expected {
+		t.Errorf("Reflow with long word failed, expected: %q, got: %q", expected, got)
+	}
+
+	// footer shaped lines of a paragraph are wrapped, trailers ending it are kept
+	prose := "Intro line.\nExample: a line that looks like a footer\nSigned-off-by: Jane Doe <jane@example.com>"
+	expected = "Intro line. Example:\na line that looks\nlike a footer\nSigned-off-by: Jane Doe <jane@example.com>"
+	if got := Reflow(prose, 20); got != expected {
+		t.Errorf("Reflow of footer shaped prose failed, expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestEditorText(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	tmpl := editorTmpl(BodyEditorTmpl)
+	if !strings.Contains(tmpl, "wrapped at 72 columns") {
+		t.Errorf("editorTmpl failed, got: %q", tmpl)
+	}
+
+	if got, expected := editorText("body line"+tmpl), "body line"; got != expected {
+		t.Errorf("editorText failed, expected: %q, got: %q", expected, got)
+	}
+
+	if msgTmpl := editorTmpl(MsgEditorTmpl); !strings.Contains(msgTmpl, "# Types: fix, feat,") {
+		t.Errorf("editorTmpl of message failed, got: %q", msgTmpl)
+	}
+}
+
+func TestBodyQuestion(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	if q := bodyQuestion(); q != cmtBodyMulti {
+		t.Error("bodyQuestion failed, expected multiline by default")
+	}
+
+	viper.Set("gitwok.commit.editor.mode", EditorModeBody)
+	q := bodyQuestion()
+	if _, ok := q.Prompt.(*survey.Editor); !ok {
+		t.Error("bodyQuestion failed, expected editor")
+	}
+	if got, expected := q.Transform("# comment\nbody\n"), "body"; got != expected {
+		t.Errorf("bodyQuestion transform failed, expected: %q, got: %q", expected, got)
+	}
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+)
+
+// ExtendsPresetPrefix prefix of built-in preset in gitwok.extends, i.e. `preset:angular`
+const ExtendsPresetPrefix = "preset:"
+
+// MergeSettings deep merge src settings over dst, nested maps are merged
+// key by key, other values including lists are replaced
+func MergeSettings(dst, src map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for k, v := range dst {
+		merged[k] = v
+	}
+	for k, v := range src {
+		sm, srcIsMap := toSettings(v)
+		dm, dstIsMap := toSettings(merged[k])
+		if srcIsMap && dstIsMap {
+			merged[k] = MergeSettings(dm, sm)
+		} else if srcIsMap {
+			merged[k] = MergeSettings(map[string]interface{}{}, sm)
+		} else {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// toSettings convert map value of config into settings map with lower case keys
+func toSettings(v interface{}) (map[string]interface{}, bool) {
+	m := map[string]interface{}{}
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, sv := range val {
+			m[strings.ToLower(k)] = sv
+		}
+	case map[interface{}]interface{}:
+		for k, sv := range val {
+			m[strings.ToLower(fmt.Sprint(k))] = sv
+		}
+	case map[string]string:
+		for k, sv := range val {
+			m[strings.ToLower(k)] = sv
+		}
+	default:
+		return nil, false
+	}
+	return m, true
+}
+
+// loadConfigFile read settings of config file, type by file extension
+func loadConfigFile(fp string) (map[string]interface{}, error) {
+	v := viper.New()
+	v.SetConfigFile(fp)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	return v.AllSettings(), nil
+}
+
+// loadExtends settings of an extends entry, preset or file relative to dir
+// @return id {string} preset entry or absolute filepath, for cycle detection
+func loadExtends(entry string, dir string) (map[string]interface{}, string, error) {
+	if strings.HasPrefix(entry, ExtendsPresetPrefix) {
+		name := strings.TrimPrefix(entry, ExtendsPresetPrefix)
+		preset, ok := Presets[name]
+		if !ok {
+			return nil, entry, fmt.Errorf("unknown preset %q in extends, choose from %v", name, PresetNames())
+		}
+		return MergeSettings(map[string]interface{}{}, map[string]interface{}{"gitwok": preset}), entry, nil
+	}
+
+	fp, err := homedir.Expand(entry)
+	if err != nil {
+		return nil, entry, err
+	}
+	if !filepath.IsAbs(fp) {
+		fp = filepath.Join(dir, fp)
+	}
+	if fp, err = filepath.Abs(fp); err != nil {
+		return nil, entry, err
+	}
+
+	settings, err := loadConfigFile(fp)
+	if err != nil {
+		return nil, fp, fmt.Errorf("failed to extend %s: %v", entry, err)
+	}
+	return settings, fp, nil
+}
+
+// ResolveExtends merge settings over those it extends in gitwok.extends in order,
+// extended files may extend others, relative paths are resolved from dir
+// @param chain {[]string} ids of configs extending settings, for cycle detection
+func ResolveExtends(settings map[string]interface{}, dir string, chain []string) (map[string]interface{}, error) {
+	var entries []string
+	if gitwok, ok := toSettings(settings["gitwok"]); ok {
+		entries = argStrs(gitwok["extends"])
+	}
+
+	merged := map[string]interface{}{}
+	for _, entry := range entries {
+		base, id, err := loadExtends(entry, dir)
+		if err != nil {
+			return nil, err
+		}
+		if containsStr(chain, id) {
+			return nil, fmt.Errorf("extends cycle: %s", strings.Join(append(chain, id), " -> "))
+		}
+
+		if !strings.HasPrefix(id, ExtendsPresetPrefix) {
+			if base, err = ResolveExtends(base, filepath.Dir(id), append(chain, id)); err != nil {
+				return nil, err
+			}
+		}
+		merged = MergeSettings(merged, base)
+	}
+
+	return MergeSettings(merged, settings), nil
+}
+
+// applyExtends merge configs extended by the config file in use
+func applyExtends() error {
+	fp := viper.ConfigFileUsed()
+	if fp == "" || len(viper.GetStringSlice("gitwok.extends")) == 0 {
+		return nil
+	}
+
+	fp, err := filepath.Abs(fp)
+	if err != nil {
+		return err
+	}
+	settings, err := loadConfigFile(fp)
+	if err != nil {
+		return err
+	}
+	merged, err := ResolveExtends(settings, filepath.Dir(fp), []string{fp})
+	if err != nil {
+		return err
+	}
+
+	logger.Verbose("Extended config by", viper.GetStringSlice("gitwok.extends"))
+	return viper.MergeConfigMap(merged)
+}
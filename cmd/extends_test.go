@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeSettings(t *testing.T) {
+	dst := map[string]interface{}{
+		"gitwok": map[string]interface{}{
+			"commit": map[string]interface{}{"type": []string{"feat", "fix"}, "enforce": "warn"},
+			"rules":  map[string]interface{}{"header-max-length": []interface{}{"error", 72}},
+		},
+	}
+	src := map[string]interface{}{
+		"gitwok": map[interface{}]interface{}{
+			"commit": map[string]interface{}{"scope": []string{"api"}, "type": []string{"docs"}},
+		},
+	}
+
+	expected := map[string]interface{}{
+		"gitwok": map[string]interface{}{
+			"commit": map[string]interface{}{"type": []string{"docs"}, "enforce": "warn", "scope": []string{"api"}},
+			"rules":  map[string]interface{}{"header-max-length": []interface{}{"error", 72}},
+		},
+	}
+	if merged := MergeSettings(dst, src); !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Expected %v, got: %v", expected, merged)
+	}
+}
+
+func writeConfig(t *testing.T, dir, name, content string) string {
+	fp := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(fp, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return fp
+}
+
+func TestResolveExtends(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gitwok-extends")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Mkdir(filepath.Join(dir, "shared"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig(t, dir, "shared/org.yaml", `
+gitwok:
+  extends: [base.yaml]
+  commit:
+    enforce: strict
+  rules:
+    header-max-length: [error, 72]
+`)
+	writeConfig(t, dir, "shared/base.yaml", `
+gitwok:
+  commit:
+    enforce: "off"
+    scope: [org]
+`)
+	fp := writeConfig(t, dir, "gitwok.yaml", `
+gitwok:
+  extends: [shared/org.yaml, "preset:jira"]
+  commit:
+    scope: [api]
+`)
+
+	settings, _ := loadConfigFile(fp)
+	merged, err := ResolveExtends(settings, dir, []string{fp})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gitwok := merged["gitwok"].(map[string]interface{})
+	commit := gitwok["commit"].(map[string]interface{})
+	if commit["enforce"] != "strict" {
+		t.Errorf("Expected enforce of org over base, got: %v", commit["enforce"])
+	}
+	if !reflect.DeepEqual(argStrs(commit["scope"]), []string{"api"}) {
+		t.Errorf("Expected own scopes, got: %v", commit["scope"])
+	}
+	if rules := gitwok["rules"].(map[string]interface{}); rules["header-max-length"] == nil || rules["ticket-required"] != SeverityError {
+		t.Errorf("Expected rules of org and preset merged, got: %v", rules)
+	}
+	if ticket, ok := gitwok["ticket"].(map[string]interface{}); !ok || ticket["target"] != TicketTargetFooter {
+		t.Errorf("Expected ticket config of preset, got: %v", gitwok["ticket"])
+	}
+}
+
+func TestResolveExtendsErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gitwok-extends")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeConfig(t, dir, "b.yaml", "gitwok:\n  extends: [a.yaml]\n")
+	a := writeConfig(t, dir, "a.yaml", "gitwok:\n  extends: [b.yaml]\n")
+	settings, _ := loadConfigFile(a)
+	if _, err := ResolveExtends(settings, dir, []string{a}); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("Expected extends cycle error, got: %v", err)
+	}
+
+	for _, entry := range []string{"missing.yaml", "preset:unknown"} {
+		settings := map[string]interface{}{"gitwok": map[string]interface{}{"extends": []interface{}{entry}}}
+		if _, err := ResolveExtends(settings, dir, []string{}); err == nil {
+			t.Errorf("Expected error extending %s", entry)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/viper"
+)
+
+const (
+	// FooterPromptLoop prompt footers one token and value at a time
+	FooterPromptLoop = "loop"
+	// FooterPromptMultiline prompt footers in one multiline input, parsed by MatchFooters
+	FooterPromptMultiline = "multiline"
+
+	// FooterOptCustom footer token option to enter a custom token
+	FooterOptCustom = "(custom token)"
+	// FooterOptDone footer token option to finish adding footers
+	FooterOptDone = "(done)"
+)
+
+// PresetFooterTokens footer tokens suggested in loop prompt
+var PresetFooterTokens = []string{"Refs", "Reviewed-by", "Co-authored-by", FTokenBrkChange}
+
+// MakeFooter join footer token and value by separator, " #" is used
+// if value starts with "#" unless token is breaking change
+func MakeFooter(token, value string) string {
+	token, value = strings.TrimSpace(token), strings.TrimSpace(value)
+	if strings.HasPrefix(value, "#") && !IsBrkChnFooter(token) {
+		return token + FSepSpaceSharp + strings.TrimPrefix(value, "#")
+	}
+	return token + FSepColonSpace + value
+}
+
+// validateFooterToken survey validator of custom footer token
+func validateFooterToken(ans interface{}) error {
+	token, _ := ans.(string)
+	if token = strings.TrimSpace(token); token == "" {
+		return errors.New(InvalidFooterToken)
+	}
+	if token != FTokenBrkChange && ContainsWhiteSpace(token) {
+		return errors.New(InvalidFooterToken)
+	}
+	return nil
+}
+
+// askFooters prompt footer token and value repeatedly until done
+func askFooters() ([]string, error) {
+	tokens := viper.GetStringSlice("gitwok.commit.footer.tokens")
+	options := append(append([]string{}, tokens...), FooterOptCustom, FooterOptDone)
+
+	footers := []string{}
+	for {
+		var token string
+		if err := survey.AskOne(&survey.Select{
+			Message: "Choose footer token:",
+			Options: options,
+			Default: FooterOptDone,
+		}, &token, askOpts()...); err != nil {
+			return footers, err
+		}
+
+		if token == FooterOptDone {
+			return footers, nil
+		}
+
+		if token == FooterOptCustom {
+			token = ""
+			if err := survey.AskOne(&survey.Input{
+				Message: "Enter footer token:",
+			}, &token, askOpts(survey.WithValidator(validateFooterToken))...); err != nil {
+				return footers, err
+			}
+		}
+
+		var value string
+		if err := survey.AskOne(&survey.Input{
+			Message: "Enter " + strings.TrimSpace(token) + " value:",
+		}, &value, askOpts(survey.WithValidator(survey.Required))...); err != nil {
+			return footers, err
+		}
+
+		footers = append(footers, MakeFooter(token, value))
+	}
+}
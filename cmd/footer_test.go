@@ -0,0 +1,43 @@
+package cmd
+
+import "testing"
+
+func TestMakeFooter(t *testing.T) {
+	var tests = []TestStr{
+		{MakeFooter("Refs", "PROJ-1"), "Refs: PROJ-1", ""},
+		{MakeFooter(" Refs ", " #12 "), "Refs #12", ""},
+		{MakeFooter("Reviewed-by", "Jane: Doe <jane@example.com>"), "Reviewed-by: Jane: Doe <jane@example.com>", ""},
+		{MakeFooter(FTokenBrkChange, "#1 was removed"), "BREAKING CHANGE: #1 was removed", ""},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("MakeFooter failed, expected: %q, got: %q", test.expected, test.got)
+		}
+	}
+
+	// footer value containing `word: ` is kept as one footer
+	cm := makeCommitMsg("fix", "", false, "desc", "", []string{MakeFooter("Refs", "note: see PROJ-1")})
+	if ok, msg := cm.Validate(); !ok {
+		t.Errorf("MakeFooter failed, expected valid footer, got: %q", msg)
+	}
+}
+
+func TestValidateFooterToken(t *testing.T) {
+	var tests = []struct {
+		token string
+		valid bool
+	}{
+		{"Acked-by", true},
+		{FTokenBrkChange, true},
+		{"", false},
+		{"  ", false},
+		{"Acked by", false},
+	}
+
+	for _, test := range tests {
+		if err := validateFooterToken(test.token); (err == nil) != test.valid {
+			t.Errorf("validateFooterToken with %q failed, expected valid: %t, got: %v", test.token, test.valid, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os/exec"
+	"strings"
 )
 
 // GitExec git executable name
@@ -13,6 +14,7 @@ const GitExec = "git"
 type Git struct {
 	verbose bool
 	dryRun  bool
+	configs []string // `-c <name>=<value>` passed to git commit
 }
 
 // hasDryRunFlag check if "--dry-run" is passed in already
@@ -32,6 +34,28 @@ func prependArg(arg string, args []string) []string {
 	return append([]string{arg}, args...)
 }
 
+// prependConfigs prepend `-c <name>=<value>` of git configs to args
+func (git *Git) prependConfigs(args []string) []string {
+	cArgs := []string{}
+	for _, c := range git.configs {
+		cArgs = append(cArgs, "-c", c)
+	}
+	return append(cArgs, args...)
+}
+
+// run exec `git <args>` and return trimmed stdout
+func (git *Git) run(args ...string) (string, error) {
+	cmd := exec.Command(GitExec, git.prependConfigs(args)...)
+	var out, errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %v %s", args[0], err, strings.TrimSpace(errOut.String()))
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
 // Status exec `git status <args>` and return stdout as bytes.Buffer
 func (git *Git) Status(args ...string) bytes.Buffer {
 	cmd := exec.Command(GitExec, prependArg("status", args)...)
@@ -42,6 +66,27 @@ func (git *Git) Status(args ...string) bytes.Buffer {
 	return out
 }
 
+// StagedFiles exec `git diff --cached --name-only` and return staged filepaths
+func (git *Git) StagedFiles() []string {
+	cmd := exec.Command(GitExec, "diff", "--cached", "--name-only", "-z")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	must(cmd.Run())
+
+	return splitNul(out.String())
+}
+
+// splitNul split `-z` output of git by NUL, drop empty entries
+func splitNul(s string) []string {
+	entries := []string{}
+	for _, entry := range strings.Split(s, "\x00") {
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
 // Add exec `git add <args>`
 func (git *Git) Add(args ...string) {
 	if !hasDryRunFlag(args) && git.dryRun {
@@ -63,18 +108,22 @@ func (git *Git) Rm(args ...string) {
 }
 
 // Commit exec `git commit <args>`
-func (git *Git) Commit(args ...string) {
+func (git *Git) Commit(args ...string) error {
 	if !hasDryRunFlag(args) && git.dryRun {
 		args = prependArg("--dry-run", args)
 	}
-	cmd := exec.Command(GitExec, prependArg("commit", args)...)
+	cmd := exec.Command(GitExec, git.prependConfigs(prependArg("commit", args))...)
 
-	var out bytes.Buffer
+	var out, errOut bytes.Buffer
 	cmd.Stdout = &out
+	cmd.Stderr = &errOut
 	err := cmd.Run()
 
-	// print output, delay exit on error
+	// print output, return error after
 	logger.Verbose(fmt.Sprintln("git commit output:") + out.String())
 
-	must(err)
+	if err != nil {
+		return fmt.Errorf("git commit: %v %s", err, strings.TrimSpace(errOut.String()))
+	}
+	return nil
 }
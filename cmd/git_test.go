@@ -21,3 +21,13 @@ func TestPrependArg(t *testing.T) {
 		t.Errorf("TestPrependArg failed, expected: %v, got: %v", expected, got)
 	}
 }
+
+func TestSplitNul(t *testing.T) {
+	if got, expected := splitNul("a.go\x00dir/file with space.go\x00"), []string{"a.go", "dir/file with space.go"}; !CompareStrSlices(got, expected) {
+		t.Errorf("TestSplitNul failed, expected: %v, got: %v", expected, got)
+	}
+
+	if got := splitNul(""); len(got) != 0 {
+		t.Errorf("TestSplitNul failed, expected empty, got: %v", got)
+	}
+}
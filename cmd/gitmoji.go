@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// GitmojiUnicode write emoji as unicode, i.e. ✨
+	GitmojiUnicode = "unicode"
+	// GitmojiShortcode write emoji as shortcode, i.e. :sparkles:
+	GitmojiShortcode = "shortcode"
+
+	// GitmojiBeforeType place emoji before type, i.e. `✨ feat: add`
+	GitmojiBeforeType = "type"
+	// GitmojiBeforeDesc place emoji before description, i.e. `feat: ✨ add`
+	GitmojiBeforeDesc = "description"
+)
+
+// Gitmoji emoji of a commit type in unicode and shortcode
+type Gitmoji struct {
+	Unicode   string
+	Shortcode string
+}
+
+// GitmojiPreset gitmoji of conventional commit types, see https://gitmoji.dev
+var GitmojiPreset = map[string]Gitmoji{
+	"feat":     {"✨", ":sparkles:"},
+	"fix":      {"🐛", ":bug:"},
+	"build":    {"👷", ":construction_worker:"},
+	"chore":    {"🔧", ":wrench:"},
+	"ci":       {"💚", ":green_heart:"},
+	"docs":     {"📝", ":memo:"},
+	"perf":     {"⚡️", ":zap:"},
+	"refactor": {"♻️", ":recycle:"},
+	"style":    {"🎨", ":art:"},
+	"test":     {"✅", ":white_check_mark:"},
+	"revert":   {"⏪️", ":rewind:"},
+}
+
+// EmojiPattern matches a leading unicode emoji sequence or shortcode and following spaces
+var EmojiPattern = regexp.MustCompile(`^(:[\w+-]+:|[\p{So}\x{FE0F}\x{200D}]+)\s+`)
+
+// EmojiEnabled check if commit emoji are used, by gitmoji or gitwok.commit.emoji,
+// leading emoji of parsed headers are only stripped if enabled
+func EmojiEnabled() bool {
+	return viper.GetBool("gitwok.commit.gitmoji.enabled") || len(viper.GetStringMapString("gitwok.commit.emoji")) > 0
+}
+
+// StripEmoji strip leading emoji of str
+// @return emoji {string} stripped emoji, "" if none
+func StripEmoji(str string) (string, string) {
+	loc := EmojiPattern.FindStringSubmatchIndex(str)
+	if loc == nil {
+		return str, ""
+	}
+	return str[loc[1]:], str[loc[2]:loc[3]]
+}
+
+// TypeEmoji emoji of commit type, gitwok.commit.emoji.<type> over
+// gitmoji preset in gitwok.commit.gitmoji.format if enabled
+func TypeEmoji(cmtType string) string {
+	cmtType = strings.ToLower(cmtType)
+	if emoji := viper.GetStringMapString("gitwok.commit.emoji")[cmtType]; emoji != "" {
+		return emoji
+	}
+	if !viper.GetBool("gitwok.commit.gitmoji.enabled") {
+		return ""
+	}
+
+	gitmoji := GitmojiPreset[cmtType]
+	if viper.GetString("gitwok.commit.gitmoji.format") == GitmojiShortcode {
+		return gitmoji.Shortcode
+	}
+	return gitmoji.Unicode
+}
+
+// PlaceEmoji insert emoji in header of msg at gitwok.commit.gitmoji.placement
+func PlaceEmoji(msg string, emoji string) string {
+	if emoji == "" {
+		return msg
+	}
+	if viper.GetString("gitwok.commit.gitmoji.placement") == GitmojiBeforeDesc {
+		return strings.Replace(msg, ": ", ": "+emoji+" ", 1)
+	}
+	return emoji + " " + msg
+}
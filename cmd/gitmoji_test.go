@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestStripEmoji(t *testing.T) {
+	testcases := []struct {
+		str, stripped, emoji string
+	}{
+		{"✨ feat: add", "feat: add", "✨"},
+		{":sparkles: feat: add", "feat: add", ":sparkles:"},
+		{"⚡️ perf: faster", "perf: faster", "⚡️"},
+		{"♻️  refactor: tidy", "refactor: tidy", "♻️"},
+		{"feat: add", "feat: add", ""},
+		{":sparkles:feat: add", ":sparkles:feat: add", ""},
+		{"^ feat: add", "^ feat: add", ""},
+	}
+
+	for _, tc := range testcases {
+		if stripped, emoji := StripEmoji(tc.str); stripped != tc.stripped || emoji != tc.emoji {
+			t.Errorf("Strip %q expected %q, %q, got: %q, %q", tc.str, tc.stripped, tc.emoji, stripped, emoji)
+		}
+	}
+}
+
+func TestTypeEmoji(t *testing.T) {
+	defer viper.Set("gitwok.commit.gitmoji.enabled", false)
+	defer viper.Set("gitwok.commit.gitmoji.format", GitmojiUnicode)
+	defer viper.Set("gitwok.commit.emoji", map[string]string{})
+
+	if emoji := TypeEmoji("feat"); emoji != "" {
+		t.Errorf("Expected no emoji if disabled, got: %q", emoji)
+	}
+
+	viper.Set("gitwok.commit.gitmoji.enabled", true)
+	if emoji := TypeEmoji("feat"); emoji != "✨" {
+		t.Errorf("Expected unicode emoji, got: %q", emoji)
+	}
+	viper.Set("gitwok.commit.gitmoji.format", GitmojiShortcode)
+	if emoji := TypeEmoji("fix"); emoji != ":bug:" {
+		t.Errorf("Expected shortcode emoji, got: %q", emoji)
+	}
+	viper.Set("gitwok.commit.emoji", map[string]string{"fix": "🚑"})
+	if emoji := TypeEmoji("fix"); emoji != "🚑" {
+		t.Errorf("Expected configured emoji, got: %q", emoji)
+	}
+}
+
+func TestPlaceEmoji(t *testing.T) {
+	defer viper.Set("gitwok.commit.gitmoji.placement", GitmojiBeforeType)
+
+	msg := "feat(api): add login\n\nRefs: ABC-1\n"
+	if placed := PlaceEmoji(msg, "✨"); placed != "✨ "+msg {
+		t.Errorf("Expected emoji before type, got: %q", placed)
+	}
+	viper.Set("gitwok.commit.gitmoji.placement", GitmojiBeforeDesc)
+	if placed := PlaceEmoji(msg, "✨"); placed != "feat(api): ✨ add login\n\nRefs: ABC-1\n" {
+		t.Errorf("Expected emoji before description, got: %q", placed)
+	}
+	if placed := PlaceEmoji(msg, ""); placed != msg {
+		t.Errorf("Expected msg unchanged without emoji, got: %q", placed)
+	}
+}
+
+func TestParseEmojiHeader(t *testing.T) {
+	// plain messages are parsed as is if emoji are not enabled
+	if _, ok := ParseCommitMsg("✨ feat(api): add login"); ok {
+		t.Error("Expected emoji header invalid if gitmoji is disabled")
+	}
+	if cm, ok := ParseCommitMsg("feat(api): ^ :sparkles: add login"); !ok || cm.Description != "^ :sparkles: add login" {
+		t.Errorf("Expected description unchanged if gitmoji is disabled, got: %+v", cm)
+	}
+
+	viper.Set("gitwok.commit.gitmoji.enabled", true)
+	defer viper.Set("gitwok.commit.gitmoji.enabled", false)
+	for _, msg := range []string{"✨ feat(api): add login", "feat(api): :sparkles: add login"} {
+		cm, ok := ParseCommitMsg(msg)
+		if !ok || cm.Type != "feat" || cm.Scope != "api" || cm.Description != "add login" {
+			t.Errorf("Parse %q expected emoji stripped, got: %+v", msg, cm)
+		}
+	}
+	if cm, ok := ParseCommitMsg("feat(api): ^ add login"); !ok || cm.Description != "^ add login" {
+		t.Errorf("Expected modifier symbol not stripped as emoji, got: %+v", cm)
+	}
+}
+
+func TestTypeEmojiRule(t *testing.T) {
+	viper.Set("gitwok.rules.type-emoji", SeverityError)
+	viper.Set("gitwok.commit.gitmoji.enabled", true)
+	defer viper.Set("gitwok.rules.type-emoji", nil)
+	defer viper.Set("gitwok.commit.gitmoji.enabled", false)
+
+	testcases := []struct {
+		msg string
+		ok  bool
+	}{
+		{"✨ feat: add login", true},
+		{"feat: :sparkles: add login", true},
+		{"🐛 feat: add login", false},
+		{"feat: add login", false},
+	}
+
+	for _, tc := range testcases {
+		if ok := !HasErrors(LintMsg(tc.msg)); ok != tc.ok {
+			t.Errorf("Lint %q expected ok %v, got: %v", tc.msg, tc.ok, LintMsg(tc.msg))
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	var tests = []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"README.md", "README.md", true},
+		{"*.md", "README.md", true},
+		{"*.md", "docs/README.md", false},
+		{"docs/**", "docs/config/config.example.yaml", true},
+		{"docs/**", "docs", true},
+		{"**/*.go", "cmd/add.go", true},
+		{"**/*.go", "main.go", true},
+		{"cmd/**/*_test.go", "cmd/add_test.go", true},
+		{"cmd/*", "cmd/sub/add.go", false},
+		{"release/*", "release/v1.0", true},
+		{"release/*", "main", false},
+		{"[", "[", false},
+	}
+
+	for _, test := range tests {
+		if got := MatchGlob(test.pattern, test.name); got != test.expected {
+			t.Errorf("MatchGlob(%q, %q) failed, expected: %t, got: %t", test.pattern, test.name, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// HookPrepareCommitMsg prepare-commit-msg hook name
+	HookPrepareCommitMsg = "prepare-commit-msg"
+	// HookCommitMsg commit-msg hook name
+	HookCommitMsg = "commit-msg"
+	// HookPrePush pre-push hook name
+	HookPrePush = "pre-push"
+	// HookPreCommit pre-commit hook name
+	HookPreCommit = "pre-commit"
+
+	// HookScriptMark marks hook scripts installed by gitwok
+	HookScriptMark = "# installed by gitwok"
+	// TTYPath controlling terminal device
+	TTYPath = "/dev/tty"
+)
+
+// HookCommands gitwok command run by each supported hook script
+var HookCommands = map[string]string{
+	HookPrepareCommitMsg: "gitwok hook prepare-commit-msg",
+	HookCommitMsg:        "gitwok lint",
+	HookPrePush:          "gitwok hook pre-push",
+	HookPreCommit:        "gitwok hook pre-commit",
+}
+
+// HookScript shell script of hook running gitwok with hook args
+func HookScript(hook string) string {
+	return fmt.Sprintf("#!/bin/sh\n%s\nexec %s \"$@\"\n", HookScriptMark, HookCommands[hook])
+}
+
+// HooksDir hooks directory of the repository, respecting core.hooksPath
+func (git *Git) HooksDir() (string, error) {
+	return git.run("rev-parse", "--git-path", "hooks")
+}
+
+// installHook write hook script, an existing hook not installed by
+// gitwok is only overwritten if force
+func installHook(dir, hook string, force bool) error {
+	fp := filepath.Join(dir, hook)
+	if bs, err := ioutil.ReadFile(fp); err == nil && !force && !strings.Contains(string(bs), HookScriptMark) {
+		return fmt.Errorf("%s hook exists, use --force to overwrite", hook)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fp, []byte(HookScript(hook)), 0755)
+}
+
+// openTTY open controlling terminal as prompt stdio, fails if not attached
+func openTTY() (*terminal.Stdio, func(), error) {
+	tty, err := os.OpenFile(TTYPath, os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &terminal.Stdio{In: tty, Out: tty, Err: tty}, func() { tty.Close() }, nil
+}
+
+// PrepareCommitMsg content of commit message file given its current content,
+// prompted message if not empty, otherwise commented conventional template,
+// followed by the original content such as git status comments
+func PrepareCommitMsg(content string, prompted string) string {
+	if prompted != "" {
+		return prompted + content
+	}
+	return strings.TrimLeft(editorTmpl(MsgEditorTmpl), "\n") + content
+}
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "run as git hooks",
+	Long:  "install and run gitwok as git hooks",
+}
+
+var hookInstallCmd = &cobra.Command{
+	Use:       "install [hook...]",
+	Short:     "install git hooks",
+	Long:      "install git hook scripts running gitwok, all supported hooks if none is given",
+	ValidArgs: []string{HookPreCommit, HookPrepareCommitMsg, HookCommitMsg, HookPrePush},
+	Args:      cobra.OnlyValidArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		git := &Git{}
+		dir, err := git.HooksDir()
+		must(err)
+
+		hooks := args
+		if len(hooks) == 0 {
+			hooks = cmd.ValidArgs
+		}
+
+		force := mustBool(cmd.LocalFlags().GetBool("force"))
+		for _, hook := range hooks {
+			must(installHook(dir, hook, force))
+			logger.Info("Installed", filepath.Join(dir, hook))
+		}
+	},
+}
+
+var hookPrepareCommitMsgCmd = &cobra.Command{
+	Use:   "prepare-commit-msg <file> [source [sha]]",
+	Short: "prepare commit message",
+	Long:  "prompt commit message if a terminal is attached, otherwise pre-fill a commented template, if no message is given to git commit",
+	Args:  cobra.RangeArgs(1, 3),
+	Run: func(cmd *cobra.Command, args []string) {
+		// source is given if message is from -m, -F, template, merge, squash or commit
+		if len(args) > 1 && args[1] != "" {
+			return
+		}
+
+		bs, err := ioutil.ReadFile(args[0])
+		must(err)
+		applyOverrides(&Git{})
+
+		var prompted string
+		if tty, closeTTY, err := openTTY(); err == nil {
+			defer closeTTY()
+			stdio = tty
+
+			var cm CommitMsg
+			if err = cm.Ask(); err == nil {
+				git := &Git{}
+				cm.Complete(git)
+				prompted, err = cm.Render(git)
+			}
+			if err != nil {
+				logger.Warn(err)
+			}
+		} else {
+			logger.Verbose(err)
+		}
+
+		must(ioutil.WriteFile(args[0], []byte(PrepareCommitMsg(string(bs), prompted)), 0644))
+	},
+}
+
+var hookPreCommitCmd = &cobra.Command{
+	Use:   "pre-commit",
+	Short: "run pre-commit tasks",
+	Long:  "run tasks of gitwok.hooks.pre-commit on staged files in parallel, restage files of restage tasks",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		git := &Git{}
+		applyOverrides(git)
+
+		tasks, parallel := HookTasks(HookPreCommit)
+		if len(tasks) == 0 {
+			return
+		}
+
+		// deleted files are not passed to tasks
+		staged := splitNul(mustStr(git.run("diff", "--cached", "--name-only", "--diff-filter=ACMR", "-z")))
+		partial := splitNul(mustStr(git.run("diff", "--name-only", "-z")))
+
+		results := RunTasks(tasks, staged, parallel)
+		if ok := PrintTaskResults(os.Stderr, results); !ok {
+			logger.Error("pre-commit tasks failed")
+			os.Exit(1)
+		}
+		must(restage(git, results, partial))
+	},
+}
+
+var hookPrePushCmd = &cobra.Command{
+	Use:   "pre-push <remote> [url]",
+	Short: "check pushed refs",
+	Long:  "lint messages of commits not on the remote yet and check names of pushed branches, ref updates are read from stdin",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		git := &Git{}
+
+		updates := ParsePushUpdates(os.Stdin)
+		ok := true
+		for _, branch := range ProtectedRefs(updates) {
+			logger.Error(fmt.Sprintf("Push to protected branch %s refused, push a branch and open a pull request", branch))
+			ok = false
+		}
+
+		// overrides apply by each branch pushed
+		violations, cvs, err := git.LintPush(args[0], updates)
+		must(err)
+		ok = logViolations(violations) && ok
+
+		if len(cvs) > 0 {
+			PrintCommitViolations(os.Stderr, cvs)
+		}
+		for _, cv := range cvs {
+			if cv.Severity == SeverityError {
+				ok = false
+			}
+		}
+		if !ok {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(hookCmd)
+	hookCmd.AddCommand(hookInstallCmd)
+	hookCmd.AddCommand(hookPrepareCommitMsgCmd)
+	hookCmd.AddCommand(hookPrePushCmd)
+	hookCmd.AddCommand(hookPreCommitCmd)
+
+	hookInstallCmd.Flags().BoolP("force", "f", false, "overwrite existing hooks")
+}
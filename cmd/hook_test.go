@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestHookScript(t *testing.T) {
+	script := HookScript(HookPrepareCommitMsg)
+	if !strings.HasPrefix(script, "#!/bin/sh\n") || !strings.Contains(script, HookScriptMark) {
+		t.Errorf("hook script should be marked sh script, got: %q", script)
+	}
+	if !strings.Contains(script, `exec gitwok hook prepare-commit-msg "$@"`) {
+		t.Errorf("hook script should exec gitwok with hook args, got: %q", script)
+	}
+}
+
+func TestInstallHook(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gitwok-hooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := installHook(dir, HookCommitMsg, false); err != nil {
+		t.Errorf("install should succeed, got: %v", err)
+	}
+	// reinstalling own hook is allowed
+	if err := installHook(dir, HookCommitMsg, false); err != nil {
+		t.Errorf("reinstall should succeed, got: %v", err)
+	}
+
+	fp := filepath.Join(dir, HookPrepareCommitMsg)
+	if err := ioutil.WriteFile(fp, []byte("#!/bin/sh\necho custom\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := installHook(dir, HookPrepareCommitMsg, false); err == nil {
+		t.Error("install should refuse to overwrite foreign hook")
+	}
+	if err := installHook(dir, HookPrepareCommitMsg, true); err != nil {
+		t.Errorf("forced install should succeed, got: %v", err)
+	}
+	if bs, _ := ioutil.ReadFile(fp); string(bs) != HookScript(HookPrepareCommitMsg) {
+		t.Errorf("forced install should overwrite hook, got: %q", string(bs))
+	}
+}
+
+func TestPrepareCommitMsg(t *testing.T) {
+	viper.Set("gitwok.commit.type", []string{"feat", "fix"})
+	defer viper.Set("gitwok.commit.type", PresetCommitTypes)
+
+	comments := "\n# Please enter the commit message for your changes.\n"
+
+	prompted := PrepareCommitMsg(comments, "feat: add hook\n")
+	if expected := "feat: add hook\n" + comments; prompted != expected {
+		t.Errorf("Expected %q, got: %q", expected, prompted)
+	}
+
+	prefilled := PrepareCommitMsg(comments, "")
+	if !strings.HasPrefix(prefilled, "# Please enter the commit message as:") ||
+		!strings.Contains(prefilled, "# Types: feat, fix") ||
+		!strings.HasSuffix(prefilled, comments) {
+		t.Errorf("template should be pre-filled before comments, got: %q", prefilled)
+	}
+	if StripComments(prefilled) != "" {
+		t.Errorf("pre-filled template should be all comments, got: %q", StripComments(prefilled))
+	}
+}
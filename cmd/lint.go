@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	// InvalidHeader error msg of header not matching `type(scope)!: description`
+	InvalidHeader = "commit header is invalid"
+	// ScissorsLine git commit --verbose marker, everything below is ignored
+	ScissorsLine = "# ------------------------ >8 ------------------------"
+)
+
+// HeaderPattern matches `type(scope)!: description`
+var HeaderPattern = regexp.MustCompile(`^([^\s():!]+)(?:\(([^()]*)\))?(!)?: (.*)$`)
+
+// FooterPattern matches the leading token and separator of a footer line
+var FooterPattern = regexp.MustCompile(`^([\w-]+|BREAKING CHANGE)(: | #)`)
+
+// IsTrailer check if line is a footer of a known kind, by configured footer
+// tokens, hyphenated tokens like `Signed-off-by`, or ` #` separator
+func IsTrailer(line string) bool {
+	m := FooterPattern.FindStringSubmatch(line)
+	if m == nil {
+		return false
+	}
+	if strings.Contains(m[1], "-") || m[1] == FTokenBrkChange || m[2] == FSepSpaceSharp {
+		return true
+	}
+	for _, token := range viper.GetStringSlice("gitwok.commit.footer.tokens") {
+		if strings.EqualFold(token, m[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// StripComments remove git comment lines and everything below scissors line
+func StripComments(str string) string {
+	lines := []string{}
+	scanner := bufio.NewScanner(strings.NewReader(str))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == ScissorsLine {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, "\r"))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// ParseCommitMsg parse raw commit message into CommitMsg
+// Footers start at the earliest paragraph from which every
+// paragraph begins with a footer token and separator, or at
+// the first footer line within the last body paragraph
+// A leading emoji before type or description is stripped if EmojiEnabled
+// @return ok {bool} false if header is not `type(scope)!: description`
+func ParseCommitMsg(str string) (*CommitMsg, bool) {
+	str = strings.TrimSpace(strings.ReplaceAll(str, "\r\n", "\n"))
+	lines := strings.Split(str, "\n")
+
+	header, emoji := lines[0], ""
+	if EmojiEnabled() {
+		header, emoji = StripEmoji(header)
+	}
+	matches := HeaderPattern.FindStringSubmatch(header)
+	if matches == nil {
+		return &CommitMsg{Description: lines[0]}, false
+	}
+	if emoji == "" && EmojiEnabled() {
+		matches[4], emoji = StripEmoji(matches[4])
+	}
+
+	// group lines after header into paragraphs
+	paragraphs := [][]string{}
+	var para []string
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			if para != nil {
+				paragraphs = append(paragraphs, para)
+				para = nil
+			}
+			continue
+		}
+		para = append(para, line)
+	}
+	if para != nil {
+		paragraphs = append(paragraphs, para)
+	}
+
+	ftStart := len(paragraphs)
+	for i := len(paragraphs) - 1; i >= 0; i-- {
+		if !FooterPattern.MatchString(paragraphs[i][0]) {
+			break
+		}
+		ftStart = i
+	}
+
+	// trailers may directly follow body lines in the last paragraph, if
+	// every line from the first one on is a trailer, not prose like `Note: ...`
+	if ftStart > 0 && ftStart == len(paragraphs) {
+		last := paragraphs[ftStart-1]
+		k := len(last)
+		for k > 1 && IsTrailer(last[k-1]) {
+			k--
+		}
+		if k < len(last) {
+			paragraphs = append(paragraphs[:ftStart-1], last[:k], last[k:])
+		}
+	}
+
+	bodyParas := []string{}
+	for _, p := range paragraphs[:ftStart] {
+		bodyParas = append(bodyParas, strings.Join(p, "\n"))
+	}
+
+	footers := []string{}
+	for _, p := range paragraphs[ftStart:] {
+		for _, line := range p {
+			if FooterPattern.MatchString(line) || len(footers) == 0 {
+				footers = append(footers, line)
+			} else {
+				footers[len(footers)-1] += "\n" + line
+			}
+		}
+	}
+
+	return &CommitMsg{
+		Type:         matches[1],
+		Scope:        matches[2],
+		HasBrkChange: matches[3] == "!",
+		Description:  matches[4],
+		Body:         strings.Join(bodyParas, "\n\n"),
+		Footers:      footers,
+		raw:          str,
+		emoji:        emoji,
+	}, true
+}
+
+// LintMsg parse and lint raw commit message
+// @return violations {[]Violation} all violations found
+func LintMsg(str string) []Violation {
+	cm, ok := ParseCommitMsg(str)
+	if !ok {
+		return []Violation{{RuleSpec, SeverityError, InvalidHeader}}
+	}
+
+	return cm.Lint()
+}
+
+func readMsg(args []string, stdin io.Reader) string {
+	var bs []byte
+	var err error
+	if len(args) > 0 {
+		bs, err = ioutil.ReadFile(args[0])
+	} else {
+		bs, err = ioutil.ReadAll(stdin)
+	}
+	must(err)
+
+	return StripComments(string(bs))
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [file]",
+	Short: "lint commit message",
+	Long:  "lint commit message from file or stdin, usable as commit-msg hook, messages of commits in --range, or the squash merge message of a pull request, reported in --format for CI",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		git := &Git{}
+
+		format := mustStr(cmd.Flags().GetString("format"))
+		if !containsStr(ReportFormats, format) {
+			logger.Fatal(fmt.Sprintf("Unknown report format: %s", format))
+		}
+
+		if rng := mustStr(cmd.Flags().GetString("range")); rng != "" {
+			if len(args) > 0 {
+				logger.Fatal("file and --range are exclusive")
+			}
+			applyBranchOverrides(git.CurrentBranch())
+			commits, err := git.LogCommits(rng)
+			must(err)
+			lintRange(format, commits)
+			return
+		}
+
+		var source, msg string
+		var violations []Violation
+		if pr, ok := readPR(cmd); ok {
+			if len(args) > 0 {
+				logger.Fatal("file and pull request flags are exclusive")
+			}
+			applyBranchOverrides(pr.Branch)
+			source = mustStr(cmd.Flags().GetString("pr-event"))
+			if source == "" {
+				source = SourcePR
+			}
+			msg = pr.Message()
+			violations = LintPR(pr)
+		} else {
+			applyOverrides(git)
+			source = SourceStdin
+			if len(args) > 0 {
+				source = args[0]
+			}
+			msg = readMsg(args, os.Stdin)
+			violations = LintMsg(msg)
+		}
+
+		if format != FormatText {
+			must(WriteReport(os.Stdout, format, []LintResult{NewLintResult(source, msg, violations)}))
+			if HasErrors(violations) {
+				os.Exit(1)
+			}
+			return
+		}
+
+		if isJSONOutput() {
+			printJSON(LintOutput{!HasErrors(violations), violations, ""})
+		}
+		if ok := logViolations(violations); !ok {
+			os.Exit(1)
+		}
+	},
+}
+
+// readPR pull request of --pr-event payload, with title and body
+// replaced by --title and --body if given
+// @return ok {bool} false if no pull request flag is given
+func readPR(cmd *cobra.Command) (PullRequest, bool) {
+	var pr PullRequest
+	event := mustStr(cmd.Flags().GetString("pr-event"))
+	if event != "" {
+		bs, err := ioutil.ReadFile(event)
+		must(err)
+		pr, err = ParsePREvent(bs)
+		must(err)
+	}
+
+	changed := event != ""
+	if cmd.Flags().Changed("title") {
+		pr.Title, changed = mustStr(cmd.Flags().GetString("title")), true
+	}
+	if cmd.Flags().Changed("body") {
+		pr.Body, changed = mustStr(cmd.Flags().GetString("body")), true
+	}
+	return pr, changed
+}
+
+// lintRange lint logged commits and report in format, exit 1 if any error
+func lintRange(format string, commits []LoggedCommit) {
+	results := []LintResult{}
+	cvs := []CommitViolation{}
+	ok := true
+	for _, c := range commits {
+		// commits of a range are not of a pushed branch, tickets are not required
+		violations := LintLogged(c, "")
+		results = append(results, NewLintResult(c.Sha, c.Message, violations))
+		for _, v := range violations {
+			cvs = append(cvs, CommitViolation{v, c.Sha, Subject(c.Message)})
+		}
+		ok = ok && !HasErrors(violations)
+	}
+
+	switch {
+	case format != FormatText:
+		must(WriteReport(os.Stdout, format, results))
+	case isJSONOutput():
+		printJSON(results)
+	case len(cvs) > 0:
+		PrintCommitViolations(os.Stderr, cvs)
+	default:
+		logger.Info(fmt.Sprintf("%d commits linted, no violation", len(commits)))
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringP("format", "f", FormatText, fmt.Sprintf("report format, one of %v", ReportFormats))
+	lintCmd.Flags().StringP("range", "r", "", "lint commits of revision range instead, i.e. origin/main..HEAD")
+	lintCmd.Flags().String("pr-event", "", "lint squash merge message of GitHub pull_request or GitLab merge_request event payload instead")
+	lintCmd.Flags().String("title", "", "lint pull request title as commit header, overrides title of --pr-event")
+	lintCmd.Flags().String("body", "", "pull request body linted as commit body and footers, overrides body of --pr-event")
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestStripComments(t *testing.T) {
+	msg := "fix: desc\r\n# comment\n\nbody\n" + ScissorsLine + "\ndiff --git a/f b/f\n"
+	if got, expected := StripComments(msg), "fix: desc\n\nbody"; got != expected {
+		t.Errorf("StripComments failed, expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestParseCommitMsg(t *testing.T) {
+	if _, ok := ParseCommitMsg("not a conventional commit"); ok {
+		t.Error("ParseCommitMsg failed, invalid header expected not ok")
+	}
+
+	raw := `feat(api)!: add token refresh
+
+first paragraph
+of body
+
+second paragraph
+
+Refs: PROJ-1
+BREAKING CHANGE: tokens expire
+after one hour
+fix #2`
+
+	cm, ok := ParseCommitMsg(raw)
+	if !ok {
+		t.Fatal("ParseCommitMsg failed, expected ok")
+	}
+
+	var tests = []TestStr{
+		{cm.Type, "feat", "type"},
+		{cm.Scope, "api", "scope"},
+		{cm.Description, "add token refresh", "description"},
+		{cm.Body, "first paragraph\nof body\n\nsecond paragraph", "body"},
+		{strings.Join(cm.Footers, "|"), "Refs: PROJ-1|BREAKING CHANGE: tokens expire\nafter one hour|fix #2", "footers"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("ParseCommitMsg %s failed, expected: %q, got: %q", test.msg, test.expected, test.got)
+		}
+	}
+
+	if !cm.HasBrkChange {
+		t.Error("ParseCommitMsg failed, expected breaking change")
+	}
+
+	// prose lines of body are not split off as footers, trailers are
+	cm, _ = ParseCommitMsg("fix: crash\n\nThis fixes the crash on start.\nNote: the cache is cleared on start.")
+	if cm.Body != "This fixes the crash on start.\nNote: the cache is cleared on start." || len(cm.Footers) != 0 {
+		t.Errorf("ParseCommitMsg failed, expected prose kept in body, got: %+v", cm)
+	}
+	cm, _ = ParseCommitMsg("fix: crash\n\nThe cache is cleared.\nSigned-off-by: Jane Doe <jane@example.com>")
+	if cm.Body != "The cache is cleared." || strings.Join(cm.Footers, "|") != "Signed-off-by: Jane Doe <jane@example.com>" {
+		t.Errorf("ParseCommitMsg failed, expected trailer split off body, got: %+v", cm)
+	}
+
+	// round trip
+	built := makeCommitMsg("docs", "readme.md", false, "fix typo", "body", []string{"Acked-by: RT"})
+	if parsed, _ := ParseCommitMsg(built.ToString()); parsed.ToString() != built.ToString() {
+		t.Errorf("ParseCommitMsg round trip failed, expected: %q, got: %q", built.ToString(), parsed.ToString())
+	}
+}
+
+func TestLintMsg(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	if vs := LintMsg("bad header"); len(vs) != 1 || vs[0].Message != InvalidHeader {
+		t.Errorf("LintMsg failed, expected: %q, got: %v", InvalidHeader, vs)
+	}
+
+	if vs := LintMsg("feat: desc\nbody without blank\n\nRefs: #1"); len(vs) != 1 || vs[0].Rule != "body-leading-blank" {
+		t.Errorf("LintMsg failed, expected body-leading-blank, got: %v", vs)
+	}
+
+	if vs := LintMsg("feat: desc\n\nbody\nRefs: #1"); len(vs) != 1 || vs[0].Rule != "footer-leading-blank" {
+		t.Errorf("LintMsg failed, expected footer-leading-blank, got: %v", vs)
+	}
+	if vs := LintMsg("fix: crash\n\nThis fixes the crash on start.\nNote: the cache is cleared on start."); len(vs) != 0 {
+		t.Errorf("LintMsg failed, expected no violation of prose body, got: %v", vs)
+	}
+}
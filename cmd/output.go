@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/Roytangrb/gitwok/util"
+)
+
+const (
+	// OutputText human readable log lines
+	OutputText = "text"
+	// OutputJSON json results on stdout, logs and prompts on stderr
+	OutputJSON = "json"
+)
+
+// LintOutput json output of lint, also of commit if the message is invalid
+// or git commit fails
+type LintOutput struct {
+	Valid      bool        `json:"valid"`
+	Violations []Violation `json:"violations"`
+	Error      string      `json:"error,omitempty"` // why commit failed
+}
+
+// CommitOutput json output of commit
+type CommitOutput struct {
+	Sha     string     `json:"sha"` // "" in dry run
+	Message string     `json:"message"`
+	Commit  *CommitMsg `json:"commit"`
+}
+
+// AddOutput json output of add
+type AddOutput struct {
+	Staged []string `json:"staged"` // all files staged after add
+}
+
+// SplitCommit commit made by split
+type SplitCommit struct {
+	Sha    string `json:"sha"` // short sha
+	Header string `json:"header"`
+}
+
+// SplitOutput json output of split, groups of changes by scope,
+// commits made and scopes skipped, none in dry run
+type SplitOutput struct {
+	Groups  []FileGroup   `json:"groups"`
+	Commits []SplitCommit `json:"commits"`
+	Skipped []string      `json:"skipped"`
+}
+
+// BranchOutput json output of branch
+type BranchOutput struct {
+	Branch string `json:"branch"`
+}
+
+// isJSONOutput check if --output json
+func isJSONOutput() bool {
+	output, _ := rootCmd.Flags().GetString("output")
+	return output == OutputJSON
+}
+
+// initOutput move logs and prompts to stderr in json output mode
+// to keep stdout for json results only, also logs of printed messages
+func initOutput() {
+	switch output := mustStr(rootCmd.Flags().GetString("output")); output {
+	case OutputJSON:
+		logToStderr()
+		stdio = &terminal.Stdio{In: os.Stdin, Out: os.Stderr, Err: os.Stderr}
+	case OutputText:
+		if printsSquashMsg() {
+			logToStderr()
+		}
+	default:
+		logger.Fatal(fmt.Sprintf("Unknown output format: %s", output))
+	}
+}
+
+// logToStderr move logs to stderr, i.e. where stdout is piped as a result
+func logToStderr() {
+	logger = util.InitLogger(os.Stderr, os.Stderr, os.Stderr, os.Stderr)
+}
+
+// printJSON write v as json line to stdout
+func printJSON(v interface{}) {
+	must(json.NewEncoder(os.Stdout).Encode(v))
+}
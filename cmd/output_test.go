@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCommitOutputJSON(t *testing.T) {
+	cm, _ := ParseCommitMsg("feat(api)!: add login\n\nRefs: ABC-1")
+	bs, err := json.Marshal(CommitOutput{"abc123", cm.ToString(), cm})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"sha":"abc123","message":"feat(api)!: add login\n\nRefs: ABC-1\n","commit":{"type":"feat","scope":"api","breaking":true,"description":"add login","body":"","footers":["Refs: ABC-1"]}}`
+	if string(bs) != expected {
+		t.Errorf("Expected %s, got: %s", expected, string(bs))
+	}
+}
+
+func TestLintOutputJSON(t *testing.T) {
+	bs, _ := json.Marshal(LintOutput{false, []Violation{{RuleSpec, SeverityError, InvalidHeader}}, ""})
+	expected := `{"valid":false,"violations":[{"rule":"spec","severity":"error","message":"commit header is invalid"}]}`
+	if string(bs) != expected {
+		t.Errorf("Expected %s, got: %s", expected, string(bs))
+	}
+}
+
+func TestCommitFailureOutputJSON(t *testing.T) {
+	bs, _ := json.Marshal(LintOutput{false, []Violation{}, ErrInvalidMsg.Error()})
+	expected := `{"valid":false,"violations":[],"error":"commit message is invalid"}`
+	if string(bs) != expected {
+		t.Errorf("Expected %s, got: %s", expected, string(bs))
+	}
+}
+
+func TestSplitOutputJSON(t *testing.T) {
+	bs, _ := json.Marshal(SplitOutput{
+		[]FileGroup{{"api", []string{"api/a b.go"}}, {"", []string{"README.md"}}},
+		[]SplitCommit{{"abc1234", "feat(api): add login"}},
+		[]string{"(no scope)"},
+	})
+	expected := `{"groups":[{"scope":"api","files":["api/a b.go"]},{"scope":"","files":["README.md"]}],"commits":[{"sha":"abc1234","header":"feat(api): add login"}],"skipped":["(no scope)"]}`
+	if string(bs) != expected {
+		t.Errorf("Expected %s, got: %s", expected, string(bs))
+	}
+}
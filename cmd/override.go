@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"github.com/spf13/viper"
+)
+
+// Override config override block of gitwok.overrides, settings apply
+// if the current branch matches any branch glob and every staged file
+// matches any path glob, a condition not given is not checked, i.e.
+//
+//	overrides:
+//	  - branches: [release/*]
+//	    commit:
+//	      type: [feat, fix]
+//	    rules:
+//	      ticket-required: error
+type Override struct {
+	Branches []string
+	Paths    []string
+	Settings map[string]interface{} // keys other than branches and paths, as under gitwok
+}
+
+// ParseOverrides parse raw config value of override blocks
+func ParseOverrides(raw interface{}) []Override {
+	overrides := []Override{}
+	items, _ := raw.([]interface{})
+	for _, item := range items {
+		block, ok := toSettings(item)
+		if !ok {
+			continue
+		}
+
+		o := Override{
+			Branches: argStrs(block["branches"]),
+			Paths:    argStrs(block["paths"]),
+			Settings: map[string]interface{}{},
+		}
+		for k, v := range block {
+			if k != "branches" && k != "paths" {
+				o.Settings[k] = v
+			}
+		}
+		overrides = append(overrides, o)
+	}
+	return overrides
+}
+
+// Matches check if override applies to branch and staged files,
+// a block without any condition never applies
+func (o Override) Matches(branch string, files []string) bool {
+	if len(o.Branches) == 0 && len(o.Paths) == 0 {
+		return false
+	}
+	if len(o.Branches) > 0 && !MatchAnyGlob(o.Branches, branch) {
+		return false
+	}
+	if len(o.Paths) > 0 {
+		if len(files) == 0 {
+			return false
+		}
+		for _, fp := range files {
+			if !MatchAnyGlob(o.Paths, fp) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// overrideSettings flattened settings of matching gitwok.overrides blocks,
+// later blocks taking precedence
+// @return applied {int} number of blocks matched
+func overrideSettings(branch string, files []string) (map[string]interface{}, int) {
+	settings := map[string]interface{}{}
+	applied := 0
+	for _, o := range ParseOverrides(viper.Get("gitwok.overrides")) {
+		if o.Matches(branch, files) {
+			flattenConfig("gitwok", o.Settings, settings)
+			applied++
+		}
+	}
+	return settings, applied
+}
+
+// ApplyOverrides set settings of matching gitwok.overrides blocks in order
+// @return applied {int} number of blocks applied
+func ApplyOverrides(branch string, files []string) int {
+	settings, applied := overrideSettings(branch, files)
+	for key, val := range settings {
+		viper.Set(key, val)
+	}
+	return applied
+}
+
+// applyOverrides apply overrides matching current branch and staged files
+func applyOverrides(git *Git) {
+	if viper.Get("gitwok.overrides") == nil {
+		return
+	}
+
+	// staged files are unknown outside of a repo, i.e. lint in CI
+	staged, _ := git.run("diff", "--cached", "--name-only", "-z")
+	branch, files := git.CurrentBranch(), splitNul(staged)
+	if n := ApplyOverrides(branch, files); n > 0 {
+		logger.Verbose("Applied", n, "config overrides of branch", branch, "and staged files")
+	}
+}
+
+// applyBranchOverrides apply overrides matching branch only, where staged
+// files are not the changes checked, i.e. commits of history, blocks
+// with path conditions never match
+func applyBranchOverrides(branch string) {
+	if viper.Get("gitwok.overrides") == nil {
+		return
+	}
+	if n := ApplyOverrides(branch, nil); n > 0 {
+		logger.Verbose("Applied", n, "config overrides of branch", branch)
+	}
+}
+
+// withBranchOverrides run fn with overrides matching branch applied, settings
+// are restored after, i.e. to check each branch of a push by its own overrides
+func withBranchOverrides(branch string, fn func()) {
+	settings, _ := overrideSettings(branch, nil)
+	saved := map[string]interface{}{}
+	for key := range settings {
+		saved[key] = viper.Get(key)
+	}
+	defer func() {
+		for key, val := range saved {
+			viper.Set(key, val)
+		}
+	}()
+
+	applyBranchOverrides(branch)
+	fn()
+}
+
+// DefaultType gitwok.commit.defaults.type if in options, otherwise the first option
+func DefaultType(options []string) string {
+	if dflt := viper.GetString("gitwok.commit.defaults.type"); containsStr(options, dflt) {
+		return dflt
+	}
+	return options[0]
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestParseOverrides(t *testing.T) {
+	raw := []interface{}{
+		map[interface{}]interface{}{
+			"branches": []interface{}{"release/*"},
+			"rules":    map[interface{}]interface{}{"ticket-required": "error"},
+		},
+		map[string]interface{}{
+			"paths":  []interface{}{"docs/**"},
+			"commit": map[string]interface{}{"defaults": map[string]interface{}{"type": "docs"}},
+		},
+		"invalid",
+	}
+
+	overrides := ParseOverrides(raw)
+	if len(overrides) != 2 {
+		t.Fatalf("Expected 2 overrides, got: %v", overrides)
+	}
+	if !reflect.DeepEqual(overrides[0].Branches, []string{"release/*"}) || len(overrides[0].Paths) != 0 {
+		t.Errorf("Expected branch condition, got: %+v", overrides[0])
+	}
+	if _, ok := overrides[1].Settings["paths"]; ok {
+		t.Errorf("Expected conditions excluded from settings, got: %v", overrides[1].Settings)
+	}
+}
+
+func TestOverrideMatches(t *testing.T) {
+	testcases := []struct {
+		o      Override
+		branch string
+		files  []string
+		ok     bool
+	}{
+		{Override{Branches: []string{"main", "release/*"}}, "release/1.2", nil, true},
+		{Override{Branches: []string{"main", "release/*"}}, "feature/login", nil, false},
+		{Override{Paths: []string{"docs/**", "*.md"}}, "main", []string{"docs/a/b.md", "README.md"}, true},
+		{Override{Paths: []string{"docs/**"}}, "main", []string{"docs/a.md", "cmd/root.go"}, false},
+		{Override{Paths: []string{"docs/**"}}, "main", []string{}, false},
+		{Override{Branches: []string{"release/*"}, Paths: []string{"docs/**"}}, "main", []string{"docs/a.md"}, false},
+		{Override{}, "main", []string{"docs/a.md"}, false},
+	}
+
+	for _, tc := range testcases {
+		if ok := tc.o.Matches(tc.branch, tc.files); ok != tc.ok {
+			t.Errorf("Override %+v on %s %v expected %v, got: %v", tc.o, tc.branch, tc.files, tc.ok, ok)
+		}
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	viper.Set("gitwok.overrides", []interface{}{
+		map[string]interface{}{
+			"branches": []interface{}{"release/*"},
+			"commit":   map[string]interface{}{"type": []interface{}{"feat", "fix"}},
+			"rules":    map[string]interface{}{"ticket-required": "error"},
+		},
+		map[string]interface{}{
+			"paths":  []interface{}{"docs/**"},
+			"commit": map[string]interface{}{"defaults": map[string]interface{}{"type": "docs"}},
+		},
+	})
+	defer viper.Set("gitwok.overrides", nil)
+	defer viper.Set("gitwok.commit.type", PresetCommitTypes)
+	defer viper.Set("gitwok.rules.ticket-required", nil)
+	defer viper.Set("gitwok.commit.defaults.type", "")
+
+	if n := ApplyOverrides("release/1.0", []string{"cmd/root.go"}); n != 1 {
+		t.Errorf("Expected 1 override applied, got: %d", n)
+	}
+	if types := viper.GetStringSlice("gitwok.commit.type"); !reflect.DeepEqual(types, []string{"feat", "fix"}) {
+		t.Errorf("Expected types overridden, got: %v", types)
+	}
+	if rule := viper.GetString("gitwok.rules.ticket-required"); rule != SeverityError {
+		t.Errorf("Expected rule overridden, got: %v", rule)
+	}
+	if dflt := DefaultType([]string{"feat", "fix"}); dflt != "feat" {
+		t.Errorf("Expected first type as default, got: %s", dflt)
+	}
+
+	ApplyOverrides("feature/x", []string{"docs/usage.md"})
+	if dflt := DefaultType([]string{"feat", "docs"}); dflt != "docs" {
+		t.Errorf("Expected default type of path override, got: %s", dflt)
+	}
+}
+
+func TestApplyBranchOverrides(t *testing.T) {
+	viper.Set("gitwok.overrides", []interface{}{
+		map[string]interface{}{
+			"branches": []interface{}{"release/*"},
+			"paths":    []interface{}{"**"},
+			"commit":   map[string]interface{}{"defaults": map[string]interface{}{"type": "docs"}},
+		},
+		map[string]interface{}{
+			"branches": []interface{}{"release/*"},
+			"commit":   map[string]interface{}{"defaults": map[string]interface{}{"type": "fix"}},
+		},
+	})
+	defer viper.Set("gitwok.overrides", nil)
+	defer viper.Set("gitwok.commit.defaults.type", "")
+
+	applyBranchOverrides("release/1.0")
+	if dflt := viper.GetString("gitwok.commit.defaults.type"); dflt != "fix" {
+		t.Errorf("Expected branch override only, got default type: %s", dflt)
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrNotPREvent payload is neither a GitHub pull_request nor a GitLab merge_request event
+var ErrNotPREvent = errors.New("event is not a pull request or merge request event")
+
+// htmlCommentPattern matches html comments of pull request templates
+var htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// blankLinesPattern matches runs of blank lines left by removed comments
+var blankLinesPattern = regexp.MustCompile(`\n\s*\n`)
+
+// PullRequest title, body and head branch of a pull request,
+// the title becomes the commit header on squash merge
+type PullRequest struct {
+	Title  string
+	Body   string
+	Branch string // head branch, "" if unknown
+}
+
+// prEvent fields of GitHub and GitLab webhook payloads
+type prEvent struct {
+	ObjectKind  string `json:"object_kind"` // GitLab event kind
+	PullRequest *struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+		Head  struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+	} `json:"pull_request"`
+	ObjectAttributes *struct {
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		SourceBranch string `json:"source_branch"`
+	} `json:"object_attributes"`
+}
+
+// ParsePREvent parse pull request of a GitHub pull_request event,
+// i.e. $GITHUB_EVENT_PATH, or a GitLab merge_request webhook payload
+func ParsePREvent(bs []byte) (PullRequest, error) {
+	var e prEvent
+	if err := json.Unmarshal(bs, &e); err != nil {
+		return PullRequest{}, err
+	}
+
+	switch {
+	case e.PullRequest != nil:
+		return PullRequest{e.PullRequest.Title, e.PullRequest.Body, e.PullRequest.Head.Ref}, nil
+	case e.ObjectAttributes != nil && e.ObjectKind == "merge_request":
+		return PullRequest{e.ObjectAttributes.Title, e.ObjectAttributes.Description, e.ObjectAttributes.SourceBranch}, nil
+	default:
+		return PullRequest{}, ErrNotPREvent
+	}
+}
+
+// Message commit message of squash merge, title as header and body
+// as body and footers, html comments of PR templates are removed
+func (pr PullRequest) Message() string {
+	msg := strings.TrimSpace(pr.Title)
+	body := htmlCommentPattern.ReplaceAllString(strings.ReplaceAll(pr.Body, "\r\n", "\n"), "")
+	body = blankLinesPattern.ReplaceAllString(body, "\n\n")
+	if body = strings.TrimSpace(body); body != "" {
+		msg += "\n\n" + body
+	}
+	return msg
+}
+
+// LintPR lint squash merge message of pull request, the branch-name and
+// ticket-required rules check the head branch instead of the current branch,
+// ticket-required is skipped if the head branch is unknown
+func LintPR(pr PullRequest) []Violation {
+	cm, ok := ParseCommitMsg(pr.Message())
+	if !ok {
+		return []Violation{{RuleSpec, SeverityError, InvalidHeader}}
+	}
+
+	cm.branch = pr.Branch
+	violations := withoutRule(cm.Lint(), RuleBranchName)
+	if pr.Branch == "" {
+		return withoutRule(violations, RuleTicketRequired)
+	}
+	return append(violations, branchNameViolations([]string{pr.Branch})...)
+}
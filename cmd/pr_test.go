@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestParsePREvent(t *testing.T) {
+	tests := []struct {
+		payload  string
+		expected PullRequest
+	}{
+		{
+			`{"action": "opened", "pull_request": {"title": "feat: add login", "body": "Adds login.", "head": {"ref": "feat/login"}}}`,
+			PullRequest{"feat: add login", "Adds login.", "feat/login"},
+		},
+		{
+			`{"pull_request": {"title": "fix: typo", "body": null, "head": {"ref": "fix/typo"}}}`,
+			PullRequest{"fix: typo", "", "fix/typo"},
+		},
+		{
+			`{"object_kind": "merge_request", "object_attributes": {"title": "docs: usage", "description": "Refs #12", "source_branch": "docs/usage"}}`,
+			PullRequest{"docs: usage", "Refs #12", "docs/usage"},
+		},
+	}
+
+	for _, test := range tests {
+		pr, err := ParsePREvent([]byte(test.payload))
+		if err != nil || pr != test.expected {
+			t.Errorf("Expected %+v, got: %+v, %v", test.expected, pr, err)
+		}
+	}
+
+	if _, err := ParsePREvent([]byte(`{"ref": "refs/heads/main"}`)); err != ErrNotPREvent {
+		t.Errorf("Expected ErrNotPREvent of push event, got: %v", err)
+	}
+	if _, err := ParsePREvent([]byte(`{"object_kind": "issue", "object_attributes": {"title": "Login fails", "description": ""}}`)); err != ErrNotPREvent {
+		t.Errorf("Expected ErrNotPREvent of GitLab issue event, got: %v", err)
+	}
+	if _, err := ParsePREvent([]byte(`{`)); err == nil {
+		t.Error("Expected error of invalid json")
+	}
+}
+
+func TestPullRequestMessage(t *testing.T) {
+	pr := PullRequest{
+		Title: " feat: add login ",
+		Body:  "<!-- describe your change -->\r\nAdds login.\r\n\r\n<!--\r\nlink issues\r\n-->\r\nRefs #12\r\n",
+	}
+	expected := "feat: add login\n\nAdds login.\n\nRefs #12"
+	if msg := pr.Message(); msg != expected {
+		t.Errorf("Expected %q, got: %q", expected, msg)
+	}
+
+	if msg := (PullRequest{Title: "fix: typo"}).Message(); msg != "fix: typo" {
+		t.Errorf("Expected title only, got: %q", msg)
+	}
+}
+
+func TestLintPR(t *testing.T) {
+	if violations := LintPR(PullRequest{Title: "Add login"}); !HasErrors(violations) || violations[0].Rule != RuleSpec {
+		t.Errorf("Expected spec error of non conventional title, got: %v", violations)
+	}
+
+	viper.Set("gitwok.rules."+RuleBranchName, SeverityError)
+	viper.Set("gitwok.branch.match", `^feat/`)
+	defer viper.Set("gitwok.rules."+RuleBranchName, nil)
+	defer viper.Set("gitwok.branch.match", nil)
+
+	violations := LintPR(PullRequest{Title: "feat: add login", Branch: "login"})
+	if len(violations) != 1 || violations[0].Rule != RuleBranchName {
+		t.Errorf("Expected branch-name violation of head branch, got: %v", violations)
+	}
+	if violations := LintPR(PullRequest{Title: "feat: add login", Branch: "feat/login"}); len(violations) != 0 {
+		t.Errorf("Expected no violation, got: %v", violations)
+	}
+}
+
+func TestLintPRTickets(t *testing.T) {
+	viper.Set("gitwok.ticket.pattern", []string{`[A-Z]+-\d+`})
+	viper.Set("gitwok.rules.ticket-required", SeverityError)
+	defer viper.Set("gitwok.ticket.pattern", []string{})
+	defer viper.Set("gitwok.rules.ticket-required", nil)
+
+	pr := PullRequest{Title: "feat: add login", Body: "Refs: PROJ-1", Branch: "feat/PROJ-1-login"}
+	if violations := LintPR(pr); len(violations) != 0 {
+		t.Errorf("Expected ticket of head branch referenced, got: %v", violations)
+	}
+	pr.Branch = "feat/PROJ-2-login"
+	if violations := LintPR(pr); len(violations) != 1 || violations[0].Rule != RuleTicketRequired {
+		t.Errorf("Expected missing ticket of head branch, got: %v", violations)
+	}
+	if violations := LintPR(PullRequest{Title: "feat: add login"}); len(violations) != 0 {
+		t.Errorf("Expected tickets not required of unknown head branch, got: %v", violations)
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// PresetConventional conventional commits, the default preset
+	PresetConventional = "conventional"
+	// PresetAngular angular commit message guidelines
+	PresetAngular = "angular"
+	// PresetAtom atom emoji prefixed commit messages
+	PresetAtom = "atom"
+	// PresetESLint eslint tag prefixed commit messages
+	PresetESLint = "eslint"
+	// PresetJira conventional commits referencing jira issues
+	PresetJira = "jira"
+)
+
+// Presets built-in configs selected by gitwok.preset, keyed as config file
+// Settings in config files override the preset, lists and maps are replaced
+// as a whole, except rules which are overridden one by one
+var Presets = map[string]map[string]interface{}{
+	PresetConventional: {
+		"commit": map[string]interface{}{
+			"type": PresetCommitTypes,
+		},
+	},
+	PresetAngular: {
+		"commit": map[string]interface{}{
+			"type":    []string{"build", "ci", "docs", "feat", "fix", "perf", "refactor", "test"},
+			"enforce": EnforceStrict,
+		},
+		"rules": map[string]interface{}{
+			"header-max-length":  []interface{}{SeverityError, 100},
+			"type-case":          []interface{}{SeverityError, CaseLower},
+			"subject-full-stop":  []interface{}{SeverityError, "."},
+			"body-leading-blank": SeverityError,
+		},
+	},
+	PresetAtom: {
+		"commit": map[string]interface{}{
+			"type": []string{"feat", "fix", "perf", "docs", "style", "test", "ci", "chore", "refactor", "build"},
+			"prompt": map[string]interface{}{
+				"scope": false,
+			},
+			"emoji": map[string]string{
+				"feat":     ":sparkles:",
+				"fix":      ":bug:",
+				"perf":     ":racehorse:",
+				"docs":     ":memo:",
+				"style":    ":art:",
+				"test":     ":white_check_mark:",
+				"ci":       ":green_heart:",
+				"chore":    ":wrench:",
+				"refactor": ":recycle:",
+				"build":    ":arrow_up:",
+			},
+			"gitmoji": map[string]interface{}{
+				"enabled":   true,
+				"format":    GitmojiShortcode,
+				"placement": GitmojiBeforeType,
+			},
+		},
+		"rules": map[string]interface{}{
+			"header-max-length": []interface{}{SeverityError, 72},
+			"type-emoji":        SeverityError,
+		},
+	},
+	PresetESLint: {
+		"commit": map[string]interface{}{
+			"type": []string{"Fix", "Update", "New", "Breaking", "Docs", "Build", "Upgrade", "Chore"},
+			"prompt": map[string]interface{}{
+				"scope":    false,
+				"breaking": false,
+			},
+			"enforce": EnforceStrict,
+		},
+		"rules": map[string]interface{}{
+			"header-max-length": []interface{}{SeverityError, 72},
+			"type-case":         []interface{}{SeverityError, CaseSentence},
+			"subject-full-stop": []interface{}{SeverityError, "."},
+		},
+	},
+	PresetJira: {
+		"commit": map[string]interface{}{
+			"type": PresetCommitTypes,
+		},
+		"ticket": map[string]interface{}{
+			"pattern": []string{`[A-Z][A-Z0-9]+-\d+`},
+			"target":  TicketTargetFooter,
+			"token":   "Refs",
+		},
+		"rules": map[string]interface{}{
+			"ticket-required": SeverityError,
+		},
+	},
+}
+
+// PresetNames sorted names of built-in presets
+func PresetNames() []string {
+	names := []string{}
+	for name := range Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PresetDefaults config keys and values of preset, nested maps are
+// flattened into dot separated keys under gitwok
+func PresetDefaults(name string) (map[string]interface{}, error) {
+	preset, ok := Presets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset %q, choose from %v", name, PresetNames())
+	}
+
+	defaults := map[string]interface{}{}
+	flattenConfig("gitwok", preset, defaults)
+	return defaults, nil
+}
+
+func flattenConfig(prefix string, m map[string]interface{}, out map[string]interface{}) {
+	for k, v := range m {
+		key := prefix + "." + k
+		if sub, ok := toSettings(v); ok {
+			flattenConfig(key, sub, out)
+		} else {
+			out[key] = v
+		}
+	}
+}
+
+// ApplyPreset set config defaults of preset in gitwok.preset, default conventional
+func ApplyPreset() error {
+	defaults, err := PresetDefaults(viper.GetString("gitwok.preset"))
+	if err != nil {
+		return err
+	}
+	for key, val := range defaults {
+		viper.SetDefault(key, val)
+	}
+	return nil
+}
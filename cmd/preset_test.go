@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPresetDefaults(t *testing.T) {
+	defaults, err := PresetDefaults(PresetAtom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"gitwok.commit.prompt.scope":     false,
+		"gitwok.commit.emoji.feat":       ":sparkles:",
+		"gitwok.commit.gitmoji.enabled":  true,
+		"gitwok.rules.type-emoji":        SeverityError,
+		"gitwok.rules.header-max-length": []interface{}{SeverityError, 72},
+	}
+	for key, val := range expected {
+		if !reflect.DeepEqual(defaults[key], val) {
+			t.Errorf("Expected %s of %v, got: %v", key, val, defaults[key])
+		}
+	}
+
+	if _, err := PresetDefaults("unknown"); err == nil {
+		t.Error("unknown preset should error")
+	}
+}
+
+func TestPresetsValid(t *testing.T) {
+	for _, name := range PresetNames() {
+		defaults, _ := PresetDefaults(name)
+		if types, ok := defaults["gitwok.commit.type"].([]string); !ok || len(types) == 0 {
+			t.Errorf("Preset %s should declare types", name)
+		}
+		for key := range defaults {
+			if rule := strings.TrimPrefix(key, "gitwok.rules."); rule != key && FindRule(rule) == nil {
+				t.Errorf("Preset %s sets unknown rule %s", name, rule)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/viper"
+)
+
+const (
+	// ProtectRefuse refuse commits on protected branches
+	ProtectRefuse = "refuse"
+	// ProtectConfirm require confirmation of commits on protected branches
+	ProtectConfirm = "confirm"
+)
+
+// ErrProtectedBranch commit on protected branch refused or aborted
+var ErrProtectedBranch = errors.New("branch is protected, create a branch to commit on by `gitwok branch`")
+
+// IsProtected check if branch matches any glob of gitwok.protect.branches
+func IsProtected(branch string) bool {
+	return branch != "" && MatchAnyGlob(viper.GetStringSlice("gitwok.protect.branches"), branch)
+}
+
+// guardBranch refuse commits on the protected branch if gitwok.protect.mode
+// is refuse, else ask to commit to a new branch named after the commit msg,
+// or to commit on the protected branch anyway
+// @return err {error} ErrProtectedBranch if refused or aborted
+func (cm *CommitMsg) guardBranch(git *Git, branch string) error {
+	if viper.GetString("gitwok.protect.mode") == ProtectRefuse {
+		return ErrProtectedBranch
+	}
+
+	newBranch, err := BranchName(cm)
+	if err != nil {
+		return err
+	}
+	optNew := fmt.Sprintf("Commit to new branch %s", newBranch)
+	optAnyway := fmt.Sprintf("Commit to %s anyway", branch)
+	optAbort := "Abort"
+
+	logger.Warn(fmt.Sprintf("Branch %s is protected", branch))
+	var choice string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Choose where to commit:",
+		Options: []string{optNew, optAnyway, optAbort},
+		Default: optNew,
+	}, &choice, askOpts()...); err != nil {
+		return err
+	}
+
+	switch choice {
+	case optNew:
+		// staged and unstaged changes are carried over to the new branch
+		return createBranch(git, newBranch)
+	case optAnyway:
+		return nil
+	default:
+		return ErrProtectedBranch
+	}
+}
+
+// Guard check current branch before commit, see guardBranch
+func (cm *CommitMsg) Guard(git *Git) error {
+	if branch := git.CurrentBranch(); IsProtected(branch) {
+		return cm.guardBranch(git, branch)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestIsProtected(t *testing.T) {
+	viper.Set("gitwok.protect.branches", []string{"main", "release/*"})
+	defer viper.Set("gitwok.protect.branches", []string{})
+
+	for branch, expected := range map[string]bool{
+		"main":          true,
+		"release/1.0":   true,
+		"feature/login": false,
+		"":              false,
+	} {
+		if protected := IsProtected(branch); protected != expected {
+			t.Errorf("Branch %q expected protected %v, got: %v", branch, expected, protected)
+		}
+	}
+}
+
+func TestGuardRefuse(t *testing.T) {
+	viper.Set("gitwok.protect.mode", ProtectRefuse)
+	defer viper.Set("gitwok.protect.mode", ProtectConfirm)
+
+	// refused without prompting, no branch is created of the message
+	cm := makeCommitMsg("feat", "", false, "", "", []string{})
+	if err := cm.guardBranch(&Git{dryRun: true}, "main"); err != ErrProtectedBranch {
+		t.Errorf("Expected %v, got: %v", ErrProtectedBranch, err)
+	}
+}
+
+func TestProtectedRefs(t *testing.T) {
+	viper.Set("gitwok.protect.branches", []string{"main", "release/*"})
+	defer viper.Set("gitwok.protect.branches", []string{})
+
+	updates := ParsePushUpdates(strings.NewReader(
+		"refs/heads/feat/login 1111111111111111111111111111111111111111 refs/heads/main " + ZeroSha + "\n" +
+			"refs/heads/feat/login 1111111111111111111111111111111111111111 refs/heads/feat/login " + ZeroSha + "\n" +
+			"refs/tags/v1.0 2222222222222222222222222222222222222222 refs/tags/v1.0 " + ZeroSha + "\n" +
+			"(delete) " + ZeroSha + " refs/heads/release/1.0 3333333333333333333333333333333333333333\n",
+	))
+	if refs := ProtectedRefs(updates); !CompareStrSlices(refs, []string{"main", "release/1.0"}) {
+		t.Errorf("Expected pushes to main and release/1.0 refused, got: %v", refs)
+	}
+}
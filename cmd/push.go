@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// ZeroSha object name of a missing ref in pre-push updates
+const ZeroSha = "0000000000000000000000000000000000000000"
+
+// PushUpdate ref update of pre-push hook stdin
+type PushUpdate struct {
+	LocalRef  string
+	LocalSha  string
+	RemoteRef string
+	RemoteSha string
+}
+
+// IsDelete check if update deletes the remote ref
+func (u PushUpdate) IsDelete() bool {
+	return u.LocalSha == ZeroSha
+}
+
+// ParsePushUpdates parse `<local ref> <local sha> <remote ref> <remote sha>` lines
+func ParsePushUpdates(r io.Reader) []PushUpdate {
+	updates := []PushUpdate{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 4 {
+			updates = append(updates, PushUpdate{fields[0], fields[1], fields[2], fields[3]})
+		}
+	}
+	return updates
+}
+
+// pushBranchViolations check names of local branches pushed by branch-name rule
+func pushBranchViolations(updates []PushUpdate) []Violation {
+	branches := []string{}
+	for _, u := range updates {
+		if branch := u.Branch(); !u.IsDelete() && branch != "" {
+			branches = append(branches, branch)
+		}
+	}
+	return branchNameViolations(branches)
+}
+
+// Branch local branch pushed, "" if the local ref is not a branch, i.e. a tag
+func (u PushUpdate) Branch() string {
+	if branch := strings.TrimPrefix(u.LocalRef, "refs/heads/"); branch != u.LocalRef {
+		return branch
+	}
+	return ""
+}
+
+// ProtectedRefs remote branches of updates protected by gitwok.protect.branches,
+// pushes to them are rejected in the pre-push hook
+func ProtectedRefs(updates []PushUpdate) []string {
+	branches := []string{}
+	for _, u := range updates {
+		if branch := strings.TrimPrefix(u.RemoteRef, "refs/heads/"); branch != u.RemoteRef && IsProtected(branch) {
+			branches = append(branches, branch)
+		}
+	}
+	return branches
+}
+
+// branchNameViolations check branch names by branch-name rule, if enabled
+func branchNameViolations(branches []string) []Violation {
+	violations := []Violation{}
+	severity, _ := FindRule(RuleBranchName).Setting()
+	if severity != SeverityWarn && severity != SeverityError {
+		return violations
+	}
+
+	for _, branch := range branches {
+		if msg := CheckBranchName(branch); msg != "" {
+			violations = append(violations, Violation{RuleBranchName, severity, msg})
+		}
+	}
+	return violations
+}
+
+// LoggedCommit commit of git log
+type LoggedCommit struct {
+	Sha     string
+	Author  string // `Name <email>`
+	Message string
+}
+
+// LogFormat git log format parsed by ParseLog, used with -z
+const LogFormat = "%H%n%an <%ae>%n%B"
+
+// ParseLog parse `git log -z --format=<LogFormat>` output
+func ParseLog(out string) []LoggedCommit {
+	commits := []LoggedCommit{}
+	for _, entry := range splitNul(out) {
+		lines := strings.SplitN(entry, "\n", 3)
+		if len(lines) < 3 {
+			continue
+		}
+		commits = append(commits, LoggedCommit{strings.TrimSpace(lines[0]), lines[1], strings.TrimSpace(lines[2])})
+	}
+	return commits
+}
+
+// LogCommits non merge commits of `git log <args>`, newest first
+func (git *Git) LogCommits(args ...string) ([]LoggedCommit, error) {
+	out, err := git.run(append([]string{"log", "-z", "--no-merges", "--format=" + LogFormat}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLog(out), nil
+}
+
+// OutgoingCommits non merge commits of update not on the remote yet, newest first
+func (git *Git) OutgoingCommits(remote string, u PushUpdate) ([]LoggedCommit, error) {
+	if u.IsDelete() {
+		return []LoggedCommit{}, nil
+	}
+
+	if u.RemoteSha != ZeroSha {
+		if commits, err := git.LogCommits(u.RemoteSha + ".." + u.LocalSha); err == nil {
+			return commits, nil
+		}
+	}
+	// new ref, or remote sha unknown locally, i.e. force push
+	return git.LogCommits(u.LocalSha, "--not", "--remotes="+remote)
+}
+
+// CommitViolation violation of a logged commit
+type CommitViolation struct {
+	Violation
+	Sha     string
+	Subject string
+}
+
+// LintLogged lint message of logged commit, branch name is checked by ref not by commit.
+// Tickets required are of branch the commit is pushed to, not of the current branch,
+// the ticket-required rule is skipped if branch is ""
+func LintLogged(c LoggedCommit, branch string) []Violation {
+	cm, ok := ParseCommitMsg(StripComments(c.Message))
+	if !ok {
+		return []Violation{{RuleSpec, SeverityError, InvalidHeader}}
+	}
+
+	cm.author = c.Author
+	cm.branch = branch
+	violations := withoutRule(cm.Lint(), RuleBranchName)
+	if branch == "" {
+		violations = withoutRule(violations, RuleTicketRequired)
+	}
+	return violations
+}
+
+// withoutRule violations not of rule
+func withoutRule(violations []Violation, rule string) []Violation {
+	filtered := []Violation{}
+	for _, v := range violations {
+		if v.Rule != rule {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// LintCommits lint messages of commits pushed to branch, see LintLogged
+func LintCommits(commits []LoggedCommit, branch string) []CommitViolation {
+	cvs := []CommitViolation{}
+	for _, c := range commits {
+		for _, v := range LintLogged(c, branch) {
+			cvs = append(cvs, CommitViolation{v, c.Sha, Subject(c.Message)})
+		}
+	}
+	return cvs
+}
+
+// LintPush check names and lint outgoing commits of pushed branches, each
+// with the overrides of the branch pushed applied, not of the current branch
+// @return violations {[]Violation} of branch names
+// @return cvs {[]CommitViolation} of outgoing commits, deduplicated
+func (git *Git) LintPush(remote string, updates []PushUpdate) ([]Violation, []CommitViolation, error) {
+	violations := []Violation{}
+	cvs := []CommitViolation{}
+	var err error
+	for _, u := range updates {
+		withBranchOverrides(u.Branch(), func() {
+			violations = append(violations, pushBranchViolations([]PushUpdate{u})...)
+
+			var commits []LoggedCommit
+			if commits, err = git.OutgoingCommits(remote, u); err != nil {
+				return
+			}
+			for _, cv := range LintCommits(commits, u.Branch()) {
+				if !containsCommitViolation(cvs, cv) {
+					cvs = append(cvs, cv)
+				}
+			}
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return violations, cvs, nil
+}
+
+// Subject first line of commit message
+func Subject(msg string) string {
+	return strings.SplitN(msg, "\n", 2)[0]
+}
+
+// shortSha abbreviated commit sha
+func shortSha(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// PrintCommitViolations print table of violations and reword suggestions
+// of commits with errors
+func PrintCommitViolations(w io.Writer, cvs []CommitViolation) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "COMMIT\tSEVERITY\tRULE\tMESSAGE\tSUBJECT")
+	toReword := []string{}
+	for _, cv := range cvs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", shortSha(cv.Sha), cv.Severity, cv.Rule, cv.Message, cv.Subject)
+		if cv.Severity == SeverityError && !containsStr(toReword, cv.Sha) {
+			toReword = append(toReword, cv.Sha)
+		}
+	}
+	tw.Flush()
+
+	if len(toReword) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nReword commits with errors by:")
+	for _, sha := range toReword {
+		fmt.Fprintf(w, "  git commit --fixup=reword:%s\n", shortSha(sha))
+	}
+	fmt.Fprintln(w, "  git rebase -i --autosquash <upstream>")
+}
+
+// containsCommitViolation check if same violation of same commit is listed,
+// i.e. commits pushed to multiple refs
+func containsCommitViolation(cvs []CommitViolation, cv CommitViolation) bool {
+	for _, c := range cvs {
+		if c == cv {
+			return true
+		}
+	}
+	return false
+}
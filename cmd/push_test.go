@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestParseLog(t *testing.T) {
+	out := "1111111111111111111111111111111111111111\nJane Doe <jane@example.com>\nfeat: add login\n\nbody\n\x00" +
+		"2222222222222222222222222222222222222222\nJohn Doe <john@example.com>\nwip\n"
+
+	commits := ParseLog(out)
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got: %v", commits)
+	}
+	expected := LoggedCommit{"1111111111111111111111111111111111111111", "Jane Doe <jane@example.com>", "feat: add login\n\nbody"}
+	if commits[0] != expected {
+		t.Errorf("Expected %+v, got: %+v", expected, commits[0])
+	}
+	if commits[1].Message != "wip" {
+		t.Errorf("Expected message wip, got: %q", commits[1].Message)
+	}
+}
+
+func TestLintCommits(t *testing.T) {
+	commits := []LoggedCommit{
+		{"1111111111111111111111111111111111111111", "Jane Doe <jane@example.com>", "feat: add login"},
+		{"2222222222222222222222222222222222222222", "John Doe <john@example.com>", "wip"},
+	}
+
+	cvs := LintCommits(commits, "")
+	if len(cvs) != 1 || cvs[0].Sha != commits[1].Sha || cvs[0].Rule != RuleSpec || cvs[0].Subject != "wip" {
+		t.Errorf("Expected spec violation of wip commit only, got: %+v", cvs)
+	}
+	if !containsCommitViolation(cvs, cvs[0]) {
+		t.Error("Expected listed violation to be found")
+	}
+}
+
+func TestLintLoggedTickets(t *testing.T) {
+	viper.Set("gitwok.ticket.pattern", []string{`[A-Z]+-\d+`})
+	viper.Set("gitwok.rules.ticket-required", SeverityError)
+	defer viper.Set("gitwok.ticket.pattern", []string{})
+	defer viper.Set("gitwok.rules.ticket-required", nil)
+
+	c := LoggedCommit{"1111111111111111111111111111111111111111", "Jane Doe <jane@example.com>", "feat: add login\n\nRefs: PROJ-1"}
+	if vs := LintLogged(c, "feat/PROJ-1-login"); len(vs) != 0 {
+		t.Errorf("Expected ticket of pushed branch referenced, got: %v", vs)
+	}
+	if vs := LintLogged(c, "feat/PROJ-2-login"); len(vs) != 1 || vs[0].Rule != RuleTicketRequired {
+		t.Errorf("Expected missing ticket of pushed branch, got: %v", vs)
+	}
+	if vs := LintLogged(LoggedCommit{c.Sha, c.Author, "feat: add login"}, ""); len(vs) != 0 {
+		t.Errorf("Expected tickets not required without branch, got: %v", vs)
+	}
+}
+
+func TestPrintCommitViolations(t *testing.T) {
+	cvs := []CommitViolation{
+		{Violation{RuleSpec, SeverityError, InvalidHeader}, "2222222222222222222222222222222222222222", "wip"},
+		{Violation{"subject-full-stop", SeverityWarn, "subject must not end with \".\""}, "3333333333333333333333333333333333333333", "fix: typo."},
+	}
+
+	var out bytes.Buffer
+	PrintCommitViolations(&out, cvs)
+	for _, expected := range []string{
+		"COMMIT",
+		"2222222  error",
+		"git commit --fixup=reword:2222222",
+		"git rebase -i --autosquash",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "reword:3333333") {
+		t.Errorf("Expected no reword suggestion of warnings, got:\n%s", out.String())
+	}
+}
+
+func TestLintPushOverrides(t *testing.T) {
+	viper.Set("gitwok.overrides", []interface{}{
+		map[string]interface{}{
+			"branches": []interface{}{"release/*"},
+			"commit":   map[string]interface{}{"type": []interface{}{"feat", "fix"}},
+			"rules":    map[string]interface{}{"type-enum": SeverityError},
+		},
+	})
+	defer viper.Set("gitwok.overrides", nil)
+	defer viper.Set("gitwok.commit.type", PresetCommitTypes)
+	defer viper.Set("gitwok.rules.type-enum", nil)
+
+	git, _, cleanup := testRepo(t)
+	defer cleanup()
+	git("commit", "-q", "--allow-empty", "-m", "feat: init")
+	git("checkout", "-q", "-b", "release/1")
+	git("commit", "-q", "--allow-empty", "-m", "chore: bump version")
+	git("checkout", "-q", "-b", "feat/x")
+
+	// release branch pushed from a feature branch checkout
+	g := &Git{}
+	sha := mustStr(g.run("rev-parse", "release/1"))
+	updates := []PushUpdate{{"refs/heads/release/1", sha, "refs/heads/release/1", ZeroSha}}
+	_, cvs, err := g.LintPush("origin", updates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cvs) != 1 || cvs[0].Rule != "type-enum" || cvs[0].Severity != SeverityError {
+		t.Errorf("Expected type-enum error of release branch override, got: %+v", cvs)
+	}
+	if types := viper.GetStringSlice("gitwok.commit.type"); !CompareStrSlices(types, PresetCommitTypes) {
+		t.Errorf("Expected overrides restored after push lint, got types: %v", types)
+	}
+
+	updates[0].LocalRef = "refs/heads/feat/x"
+	if _, cvs, _ := g.LintPush("origin", updates); len(cvs) != 0 {
+		t.Errorf("Expected no violation without override, got: %+v", cvs)
+	}
+}
@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// FormatText log lines, or a violation table of commit range
+	FormatText = "text"
+	// FormatJUnit JUnit XML, one test case per commit message
+	FormatJUnit = "junit"
+	// FormatSARIF SARIF 2.1.0 JSON for code scanning
+	FormatSARIF = "sarif"
+	// FormatGitHub GitHub Actions workflow command annotations
+	FormatGitHub = "github"
+	// FormatCheckstyle checkstyle XML, one file per commit message
+	FormatCheckstyle = "checkstyle"
+)
+
+// ReportFormats formats of lint reports
+var ReportFormats = []string{FormatText, FormatJUnit, FormatSARIF, FormatGitHub, FormatCheckstyle}
+
+const (
+	// SourceStdin source of message read from stdin
+	SourceStdin = "stdin"
+	// SourcePR source of pull request message without event payload
+	SourcePR = "pull request"
+)
+
+var shaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// LintResult violations of a linted commit message
+type LintResult struct {
+	Source     string      `json:"source"` // file path, stdin or commit sha
+	Subject    string      `json:"subject"`
+	Valid      bool        `json:"valid"`
+	Violations []Violation `json:"violations"`
+}
+
+// NewLintResult result of violations of source message
+func NewLintResult(source, msg string, violations []Violation) LintResult {
+	return LintResult{source, Subject(msg), !HasErrors(violations), violations}
+}
+
+// name test case name of result, source and subject, sha of commits shortened
+func (r LintResult) name() string {
+	source := r.Source
+	if shaPattern.MatchString(source) {
+		source = shortSha(source)
+	}
+	if r.Subject == "" {
+		return source
+	}
+	return source + " " + r.Subject
+}
+
+// WriteReport write lint results in format
+func WriteReport(w io.Writer, format string, results []LintResult) error {
+	switch format {
+	case FormatJUnit:
+		return writeJUnit(w, results)
+	case FormatSARIF:
+		return writeSARIF(w, results)
+	case FormatGitHub:
+		return writeGitHub(w, results)
+	case FormatCheckstyle:
+		return writeCheckstyle(w, results)
+	default:
+		return fmt.Errorf("unknown report format %q, choose from %v", format, ReportFormats)
+	}
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit one test case per message, failed by errors, warnings in system-out
+func writeJUnit(w io.Writer, results []LintResult) error {
+	suite := junitTestSuite{Name: "gitwok lint", Tests: len(results)}
+	for _, r := range results {
+		tc := junitTestCase{ClassName: "gitwok.lint", Name: r.name()}
+		errs, warns := []string{}, []string{}
+		for _, v := range r.Violations {
+			if v.Severity == SeverityError {
+				if tc.Failure == nil {
+					tc.Failure = &junitFailure{Type: v.Rule, Message: v.Message}
+				}
+				errs = append(errs, v.String())
+			} else {
+				warns = append(warns, v.String())
+			}
+		}
+		if tc.Failure != nil {
+			tc.Failure.Text = strings.Join(errs, "\n")
+			suite.Failures++
+		}
+		tc.SystemOut = strings.Join(warns, "\n")
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	return writeXML(w, junitTestSuites{Name: suite.Name, Tests: suite.Tests, Failures: suite.Failures, Suites: []junitTestSuite{suite}})
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// writeCheckstyle one file per message, violations reported at line 1
+func writeCheckstyle(w io.Writer, results []LintResult) error {
+	report := checkstyleReport{Version: "4.3"}
+	for _, r := range results {
+		file := checkstyleFile{Name: r.Source}
+		for _, v := range r.Violations {
+			severity := "warning"
+			if v.Severity == SeverityError {
+				severity = "error"
+			}
+			file.Errors = append(file.Errors, checkstyleError{1, severity, v.Message, "gitwok." + v.Rule})
+		}
+		report.Files = append(report.Files, file)
+	}
+	return writeXML(w, report)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// DefaultConfigFile config file results of messages not in files are located at
+// if no config file is used
+const DefaultConfigFile = "gitwok.yaml"
+
+// configFilePath path of config file used relative to working directory,
+// DefaultConfigFile if none
+func configFilePath() string {
+	fp := viper.ConfigFileUsed()
+	if fp == "" {
+		return DefaultConfigFile
+	}
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, fp); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(fp)
+}
+
+// sarifLocationOf location at line 1 of message file, commits, stdin and pull
+// requests are not files of the repo, they are located logically, and physically
+// at the config file of the rules, which code scanning requires to show results
+func sarifLocationOf(r LintResult) sarifLocation {
+	uri := r.Source
+	var logical []sarifLogicalLocation
+	switch {
+	case shaPattern.MatchString(r.Source):
+		uri, logical = configFilePath(), []sarifLogicalLocation{{shortSha(r.Source), r.Source, "commit"}}
+	case r.Source == SourceStdin || r.Source == SourcePR:
+		uri, logical = configFilePath(), []sarifLogicalLocation{{Name: r.Source, Kind: "message"}}
+	}
+	return sarifLocation{
+		PhysicalLocation: &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{uri},
+			Region:           sarifRegion{1},
+		},
+		LogicalLocations: logical,
+	}
+}
+
+// lineHash primaryLocationLineHash of the subject line of result source
+func lineHash(r LintResult) string {
+	sum := sha256.Sum256([]byte(r.Source + "\x00" + r.Subject))
+	return fmt.Sprintf("%x:1", sum[:8])
+}
+
+// writeSARIF one result per violation, located at line 1 of message source
+func writeSARIF(w io.Writer, results []LintResult) error {
+	run := sarifRun{
+		Tool: sarifTool{sarifDriver{
+			Name:           "gitwok",
+			InformationURI: "https://github.com/Roytangrb/gitwok",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	ruleIDs := []string{}
+	for _, r := range results {
+		for _, v := range r.Violations {
+			level := "warning"
+			if v.Severity == SeverityError {
+				level = "error"
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:              v.Rule,
+				Level:               level,
+				Message:             sarifMessage{fmt.Sprintf("%s: %s", r.name(), v.Message)},
+				Locations:           []sarifLocation{sarifLocationOf(r)},
+				PartialFingerprints: map[string]string{"primaryLocationLineHash": lineHash(r)},
+			})
+			if !containsStr(ruleIDs, v.Rule) {
+				ruleIDs = append(ruleIDs, v.Rule)
+			}
+		}
+	}
+	sort.Strings(ruleIDs)
+	for _, id := range ruleIDs {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{id, sarifMessage{RuleDescription(id)}})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{"2.1.0", "https://json.schemastore.org/sarif-2.1.0.json", []sarifRun{run}})
+}
+
+// escapeGitHub escape workflow command data, and property values if prop
+func escapeGitHub(s string, prop bool) string {
+	s = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+	if prop {
+		s = strings.NewReplacer(":", "%3A", ",", "%2C").Replace(s)
+	}
+	return s
+}
+
+// writeGitHub one `::error` or `::warning` annotation per violation
+func writeGitHub(w io.Writer, results []LintResult) error {
+	for _, r := range results {
+		for _, v := range r.Violations {
+			cmd := "warning"
+			if v.Severity == SeverityError {
+				cmd = "error"
+			}
+			title := escapeGitHub("gitwok "+v.Rule, true)
+			if _, err := fmt.Fprintf(w, "::%s title=%s::%s\n", cmd, title, escapeGitHub(r.name()+": "+v.Message, false)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
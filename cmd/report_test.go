@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+var reportResults = []LintResult{
+	NewLintResult("1111111111111111111111111111111111111111", "feat: add login", []Violation{}),
+	NewLintResult("2222222222222222222222222222222222222222", "wip", []Violation{
+		{RuleSpec, SeverityError, InvalidHeader},
+	}),
+	NewLintResult(".git/COMMIT_EDITMSG", "fix: typo.", []Violation{
+		{"subject-full-stop", SeverityWarn, "subject must not end with \".\""},
+	}),
+}
+
+func TestNewLintResult(t *testing.T) {
+	if r := reportResults[1]; r.Valid || r.Subject != "wip" || r.name() != "2222222 wip" {
+		t.Errorf("Expected invalid result named by short sha, got: %+v, %s", r, r.name())
+	}
+	if r := reportResults[2]; !r.Valid || r.name() != ".git/COMMIT_EDITMSG fix: typo." {
+		t.Errorf("Expected valid result named by file path, got: %+v, %s", r, r.name())
+	}
+}
+
+func TestWriteReportJUnit(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatJUnit, reportResults); err != nil {
+		t.Fatal(err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(out.Bytes(), &suites); err != nil {
+		t.Fatalf("Expected valid xml, got: %v\n%s", err, out.String())
+	}
+	if suites.Tests != 3 || suites.Failures != 1 || len(suites.Suites[0].TestCases) != 3 {
+		t.Errorf("Expected 3 tests with 1 failure, got:\n%s", out.String())
+	}
+	if f := suites.Suites[0].TestCases[1].Failure; f == nil || f.Type != RuleSpec {
+		t.Errorf("Expected failure of rule %s, got: %+v", RuleSpec, f)
+	}
+	if tc := suites.Suites[0].TestCases[2]; tc.Failure != nil || !strings.Contains(tc.SystemOut, "subject-full-stop") {
+		t.Errorf("Expected warning in system-out only, got: %+v", tc)
+	}
+}
+
+func TestWriteReportSARIF(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatSARIF, reportResults); err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("Expected valid json, got: %v\n%s", err, out.String())
+	}
+	run := log.Runs[0]
+	if log.Version != "2.1.0" || len(run.Results) != 2 || len(run.Tool.Driver.Rules) != 2 {
+		t.Errorf("Expected 2 results of 2 rules, got:\n%s", out.String())
+	}
+	for _, r := range run.Results {
+		if len(r.Locations) == 0 || r.Locations[0].PhysicalLocation == nil || r.Locations[0].PhysicalLocation.ArtifactLocation.URI == "" {
+			t.Errorf("Expected physical location of every result for code scanning, got: %+v", r)
+		}
+	}
+	if r := run.Results[0]; r.RuleID != RuleSpec || r.Level != "error" || r.Locations[0].PhysicalLocation.ArtifactLocation.URI != DefaultConfigFile ||
+		r.Locations[0].LogicalLocations[0].FullyQualifiedName != reportResults[1].Source {
+		t.Errorf("Expected spec error located at config file and logically at commit sha, got: %+v", r)
+	}
+	if r := run.Results[1]; r.Level != "warning" || r.Locations[0].PhysicalLocation.ArtifactLocation.URI != reportResults[2].Source {
+		t.Errorf("Expected warning located at message file, got: %+v", r)
+	}
+	if h := run.Results[0].PartialFingerprints["primaryLocationLineHash"]; !strings.HasSuffix(h, ":1") || h == run.Results[1].PartialFingerprints["primaryLocationLineHash"] {
+		t.Errorf("Expected distinct line hashes of results, got: %v", run.Results)
+	}
+	for _, rule := range run.Tool.Driver.Rules {
+		if rule.ShortDescription.Text == "" {
+			t.Errorf("Expected short description of rule %s", rule.ID)
+		}
+	}
+}
+
+func TestWriteReportGitHub(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatGitHub, reportResults); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "::error title=gitwok spec::2222222 wip: " + InvalidHeader + "\n" +
+		"::warning title=gitwok subject-full-stop::.git/COMMIT_EDITMSG fix: typo.: subject must not end with \".\"\n"
+	if out.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+	if got := escapeGitHub("a:b,c%\nd", true); got != "a%3Ab%2Cc%25%0Ad" {
+		t.Errorf("Expected escaped property, got: %s", got)
+	}
+}
+
+func TestWriteReportCheckstyle(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteReport(&out, FormatCheckstyle, reportResults); err != nil {
+		t.Fatal(err)
+	}
+
+	var report checkstyleReport
+	if err := xml.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Expected valid xml, got: %v\n%s", err, out.String())
+	}
+	if len(report.Files) != 3 || len(report.Files[0].Errors) != 0 {
+		t.Errorf("Expected 3 files, got:\n%s", out.String())
+	}
+	if e := report.Files[1].Errors[0]; e.Severity != "error" || e.Source != "gitwok."+RuleSpec || e.Line != 1 {
+		t.Errorf("Expected spec error at line 1, got: %+v", e)
+	}
+}
+
+func TestWriteReportUnknown(t *testing.T) {
+	if err := WriteReport(&bytes.Buffer{}, "tap", reportResults); err == nil {
+		t.Error("Expected error of unknown format")
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -32,22 +33,56 @@ func Execute() {
 }
 
 func init() {
-	cobra.OnInitialize(initDefaults, readConfig)
+	cobra.OnInitialize(initOutput, initDefaults, readConfig)
 
 	rootCmd.SetVersionTemplate(VersionTmpl)
 
 	rootCmd.PersistentFlags().String("config", "", "config file (default is ./gitwok.yaml)")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolP("dry-run", "n", false, "dry run all git exec actions")
+	rootCmd.PersistentFlags().String("output", OutputText, "output format: text | json")
 }
 
 func initDefaults() {
+	viper.SetDefault("gitwok.preset", PresetConventional)
 	viper.SetDefault("gitwok.commit.prompt.scope", true)
 	viper.SetDefault("gitwok.commit.prompt.breaking", true)
 	viper.SetDefault("gitwok.commit.prompt.body", true)
 	viper.SetDefault("gitwok.commit.prompt.footers", true)
+	viper.SetDefault("gitwok.commit.prompt.multiscope", false)
+	viper.SetDefault("gitwok.commit.prompt.coauthors", false)
 	viper.SetDefault("gitwok.commit.type", PresetCommitTypes)
 	viper.SetDefault("gitwok.commit.scope", []string{})
+	viper.SetDefault("gitwok.commit.delimiter.scope", ",")
+	viper.SetDefault("gitwok.commit.delimiter.subscope", "/")
+	viper.SetDefault("gitwok.commit.footer.prompt", FooterPromptLoop)
+	viper.SetDefault("gitwok.commit.footer.tokens", PresetFooterTokens)
+	viper.SetDefault("gitwok.commit.coauthors", []string{})
+	viper.SetDefault("gitwok.commit.breaking.footer", false)
+	viper.SetDefault("gitwok.commit.editor.mode", EditorModeOff)
+	viper.SetDefault("gitwok.commit.editor.wrap", 72)
+	viper.SetDefault("gitwok.commit.template", "")
+	viper.SetDefault("gitwok.commit.templates", map[string]string{})
+	viper.SetDefault("gitwok.commit.emoji", map[string]string{})
+	viper.SetDefault("gitwok.commit.gitmoji.enabled", false)
+	viper.SetDefault("gitwok.commit.gitmoji.format", GitmojiUnicode)
+	viper.SetDefault("gitwok.commit.gitmoji.placement", GitmojiBeforeType)
+	viper.SetDefault("gitwok.commit.defaults.type", "")
+	viper.SetDefault("gitwok.commit.enforce", EnforceWarn)
+	viper.SetDefault("gitwok.commit.signoff", false)
+	viper.SetDefault("gitwok.commit.sign.enabled", false)
+	viper.SetDefault("gitwok.commit.sign.key", "")
+	viper.SetDefault("gitwok.commit.sign.format", "")
+	viper.SetDefault("gitwok.branch.pattern", BranchPatternTmpl)
+	viper.SetDefault("gitwok.branch.match", "")
+	viper.SetDefault("gitwok.branch.ignore", []string{"main", "master", "develop"})
+	viper.SetDefault("gitwok.protect.branches", []string{})
+	viper.SetDefault("gitwok.protect.mode", ProtectConfirm)
+	viper.SetDefault("gitwok.hooks.pre-commit.parallel", 4)
+	viper.SetDefault("gitwok.hooks.pre-commit.tasks", []interface{}{})
+	viper.SetDefault("gitwok.ticket.pattern", []string{})
+	viper.SetDefault("gitwok.ticket.target", TicketTargetFooter)
+	viper.SetDefault("gitwok.ticket.token", "Refs")
 }
 
 func readConfig() {
@@ -71,6 +106,10 @@ func readConfig() {
 
 	if err := viper.ReadInConfig(); err == nil {
 		logger.Verbose("Using config file", viper.ConfigFileUsed())
+		// shared rules must not silently stop applying
+		if err := applyExtends(); err != nil {
+			logger.Fatal(fmt.Sprintf("Failed to extend config: %v", err))
+		}
 	} else {
 		if fnfe, ok := err.(viper.ConfigFileNotFoundError); ok {
 			logger.Warn(fnfe)
@@ -80,6 +119,10 @@ func readConfig() {
 			logger.Warn(err)
 		}
 	}
+
+	if err := ApplyPreset(); err != nil {
+		logger.Warn(err)
+	}
 }
 
 func must(err error) {
@@ -2,6 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -37,6 +41,40 @@ func CompareStrSlices(s1 []string, s2 []string) bool {
 	return true
 }
 
+// testRepo init a git repo in a temp dir as working directory
+// @return git {func} run git with a test identity, fail test on error
+// @return write {func} write file of path, parent dirs created
+// @return cleanup {func} restore working directory and remove repo
+func testRepo(t *testing.T) (git func(args ...string), write func(fp, content string), cleanup func()) {
+	dir, err := ioutil.TempDir("", "gitwok-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	cleanup = func() {
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+	}
+
+	git = func(args ...string) {
+		args = append([]string{"-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com"}, args...)
+		if out, err := exec.Command(GitExec, args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v %s", args, err, out)
+		}
+	}
+	write = func(fp, content string) {
+		os.MkdirAll(filepath.Dir(fp), 0755)
+		if err := ioutil.WriteFile(fp, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	return git, write, cleanup
+}
+
 func TestInitDefaults(t *testing.T) {
 	// reset all to default settings
 	viper.Reset()
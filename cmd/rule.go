@@ -0,0 +1,539 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// SeverityOff rule is disabled
+	SeverityOff = "off"
+	// SeverityWarn rule violation is reported as warning
+	SeverityWarn = "warn"
+	// SeverityError rule violation is reported as error and rejects the commit
+	SeverityError = "error"
+
+	// RuleSpec name of the conventional commits spec checks, always error
+	RuleSpec = "spec"
+	// RuleBranchName rule name of branch name check, also applied by pre-push hook
+	RuleBranchName = "branch-name"
+	// RuleTicketRequired rule name of ticket references, checked against tickets of the linted branch
+	RuleTicketRequired = "ticket-required"
+
+	// CaseLower lower-case
+	CaseLower = "lower-case"
+	// CaseUpper UPPER-CASE
+	CaseUpper = "upper-case"
+	// CaseSentence Sentence case
+	CaseSentence = "sentence-case"
+	// CaseStart Start Case
+	CaseStart = "start-case"
+	// CaseCamel camelCase
+	CaseCamel = "camel-case"
+	// CasePascal PascalCase
+	CasePascal = "pascal-case"
+	// CaseKebab kebab-case
+	CaseKebab = "kebab-case"
+	// CaseSnake snake_case
+	CaseSnake = "snake-case"
+)
+
+// Violation of a lint rule
+type Violation struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String format violation as `message [rule]`
+func (v Violation) String() string {
+	return fmt.Sprintf("%s [%s]", v.Message, v.Rule)
+}
+
+// Rule named check of a commit msg, configurable by
+// `gitwok.rules.<name>: <severity>` or `[<severity>, <arg>]`
+type Rule struct {
+	Name        string
+	Description string      // what the rule checks, i.e. in sarif reports
+	Severity    string      // default severity, "" defers to gitwok.commit.enforce
+	Arg         interface{} // default argument
+	// Check return violation msg, or "" if passed
+	Check func(cm *CommitMsg, arg interface{}) string
+}
+
+// Rules registered lint rules, checked in order
+var Rules = []*Rule{
+	{
+		Name:        "header-max-length",
+		Description: "header must not exceed the max length",
+		Severity:    SeverityWarn,
+		Arg:         100,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if n := len([]rune(cm.Header())); n > argInt(arg) {
+				return fmt.Sprintf("header must not be longer than %d characters, current length is %d", argInt(arg), n)
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "type-case",
+		Description: "type must be in the configured case",
+		Severity:    SeverityWarn,
+		Arg:         CaseLower,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if !matchCases(cm.Type, argStrs(arg)) {
+				return fmt.Sprintf("type must be in %s", strings.Join(argStrs(arg), " or "))
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "type-enum",
+		Description: "type must be one of the configured types",
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			options := argStrs(arg)
+			if arg == nil {
+				options = viper.GetStringSlice("gitwok.commit.type")
+			}
+			return checkEnum(UnknownType, cm.Type, options)
+		},
+	},
+	{
+		Name:        "scope-enum",
+		Description: "scopes must be of the configured scopes",
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if s, ok := arg.(string); ok {
+				arg = []string{s}
+			}
+			options := FlattenScopes(ParseScopeDefs(arg))
+			if arg == nil {
+				options = ScopeOptions()
+			}
+			for _, scope := range SplitScopes(cm.Scope) {
+				if msg := checkEnum(UnknownScope, scope, options); msg != "" {
+					return msg
+				}
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "scope-case",
+		Description: "scope must be in the configured case",
+		Severity:    SeverityOff,
+		Arg:         CaseLower,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if cm.Scope != "" && !matchCases(cm.Scope, argStrs(arg)) {
+				return fmt.Sprintf("scope must be in %s", strings.Join(argStrs(arg), " or "))
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "subject-case",
+		Description: "subject must be in the configured case",
+		Severity:    SeverityOff,
+		Arg:         CaseLower,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if !matchCases(cm.Description, argStrs(arg)) {
+				return fmt.Sprintf("subject must be in %s", strings.Join(argStrs(arg), " or "))
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "subject-full-stop",
+		Description: "subject must not end with the full stop",
+		Severity:    SeverityWarn,
+		Arg:         ".",
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if stop := argStr(arg); stop != "" && strings.HasSuffix(cm.Description, stop) {
+				return fmt.Sprintf("subject must not end with %q", stop)
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "body-leading-blank",
+		Description: "body must have a leading blank line",
+		Severity:    SeverityWarn,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if lines := strings.Split(cm.raw, "\n"); len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+				return "body must have leading blank line"
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "body-max-line-length",
+		Description: "body lines must not exceed the max length",
+		Severity:    SeverityWarn,
+		Arg:         100,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if maxLineLength(cm.Body) > argInt(arg) {
+				return fmt.Sprintf("body's lines must not be longer than %d characters", argInt(arg))
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "footer-leading-blank",
+		Description: "footers must have a leading blank line",
+		Severity:    SeverityWarn,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if len(cm.Footers) == 0 || cm.raw == "" {
+				return ""
+			}
+			lines := strings.Split(cm.raw, "\n")
+			first := strings.SplitN(cm.Footers[0], "\n", 2)[0]
+			for i := len(lines) - 1; i > 0; i-- {
+				if lines[i] == first {
+					if strings.TrimSpace(lines[i-1]) != "" {
+						return "footer must have leading blank line"
+					}
+					break
+				}
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "footer-max-line-length",
+		Description: "footer lines must not exceed the max length",
+		Severity:    SeverityWarn,
+		Arg:         100,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if maxLineLength(strings.Join(cm.Footers, "\n")) > argInt(arg) {
+				return fmt.Sprintf("footer's lines must not be longer than %d characters", argInt(arg))
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "breaking-change-footer",
+		Description: `breaking changes must be marked by "!" and described in a BREAKING CHANGE footer`,
+		Severity:    SeverityOff,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if hasFooter := cm.HasBrkChnFooter(); cm.HasBrkChange && !hasFooter {
+				return fmt.Sprintf("breaking change marked by \"!\" must be described in %s footer", FTokenBrkChange)
+			} else if !cm.HasBrkChange && hasFooter {
+				return fmt.Sprintf("%s footer must be marked by \"!\" in header", FTokenBrkChange)
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "type-emoji",
+		Description: "header must have the emoji of the type",
+		Severity:    SeverityOff,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			emoji := TypeEmoji(cm.Type)
+			if emoji == "" || cm.emoji == emoji {
+				return ""
+			}
+			// either form of preset gitmoji is recognized
+			if g, ok := GitmojiPreset[strings.ToLower(cm.Type)]; ok && (emoji == g.Unicode || emoji == g.Shortcode) &&
+				(cm.emoji == g.Unicode || cm.emoji == g.Shortcode) {
+				return ""
+			}
+			return fmt.Sprintf("header of type %s must have emoji %s", cm.Type, emoji)
+		},
+	},
+	{
+		Name:        RuleBranchName,
+		Description: "branch name must match the branch pattern",
+		Severity:    SeverityOff,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			return CheckBranchName((&Git{}).CurrentBranch())
+		},
+	},
+	{
+		Name:        "signed-off-by",
+		Description: "commit must be signed off by the author",
+		Severity:    SeverityOff,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			author := cm.author
+			if author == "" {
+				author = (&Git{}).AuthorIdent()
+			}
+			for _, ident := range cm.SignedOffBy() {
+				if strings.EqualFold(ident, author) {
+					return ""
+				}
+			}
+			return fmt.Sprintf("commit must be signed off by the author %s", author)
+		},
+	},
+	{
+		Name:        RuleTicketRequired,
+		Description: "commit must reference the tickets of the branch",
+		Severity:    SeverityError,
+		Check: func(cm *CommitMsg, arg interface{}) string {
+			if missing := cm.missingTickets(cm.branchTickets()); len(missing) > 0 {
+				return fmt.Sprintf("commit must reference ticket %s of the branch", strings.Join(missing, ", "))
+			}
+			return ""
+		},
+	},
+}
+
+// RuleDescription description of rule by name, also of the spec checks
+func RuleDescription(name string) string {
+	if name == RuleSpec {
+		return "message must follow the conventional commits spec"
+	}
+	if r := FindRule(name); r != nil {
+		return r.Description
+	}
+	return ""
+}
+
+// FindRule return registered rule by name, nil if not found
+func FindRule(name string) *Rule {
+	for _, r := range Rules {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// Setting return configured severity and argument of rule,
+// fallback to rule defaults
+func (r *Rule) Setting() (severity string, arg interface{}) {
+	severity, arg = r.Severity, r.Arg
+	if severity == "" {
+		severity = enforceSeverity()
+	}
+
+	switch v := viper.Get("gitwok.rules." + r.Name).(type) {
+	case string, bool:
+		severity = normalizeSeverity(v)
+	case []interface{}:
+		if len(v) > 0 {
+			severity = normalizeSeverity(v[0])
+		}
+		if len(v) > 1 {
+			arg = v[1]
+		}
+	case []string:
+		if len(v) > 0 {
+			severity = v[0]
+		}
+		if len(v) > 1 {
+			arg = v[1]
+		}
+	}
+
+	// invalid config falls back to rule defaults instead of changing lint results
+	if severity != SeverityOff && severity != SeverityWarn && severity != SeverityError {
+		fallback := r.Severity
+		if fallback == "" {
+			fallback = enforceSeverity()
+		}
+		warnConfig(fmt.Sprintf("Unknown severity %q of rule %s, using %s", severity, r.Name, fallback))
+		severity = fallback
+	}
+	if _, isInt := r.Arg.(int); isInt {
+		if _, err := strconv.Atoi(fmt.Sprint(arg)); err != nil {
+			warnConfig(fmt.Sprintf("Invalid argument %v of rule %s, using %d", arg, r.Name, r.Arg))
+			arg = r.Arg
+		}
+	}
+
+	return severity, arg
+}
+
+// warnedConfig config warnings logged, to log each once per run
+var warnedConfig = map[string]bool{}
+
+// warnConfig log warning of invalid config once
+func warnConfig(msg string) {
+	if !warnedConfig[msg] {
+		warnedConfig[msg] = true
+		logger.Warn(msg)
+	}
+}
+
+// warnUnknownRules warn of configured rules not registered, likely typos
+func warnUnknownRules() {
+	for name := range viper.GetStringMap("gitwok.rules") {
+		if FindRule(name) == nil {
+			warnConfig(enumMsg("Unknown rule in gitwok.rules", name, RuleNames()))
+		}
+	}
+}
+
+// RuleNames names of registered rules
+func RuleNames() []string {
+	names := []string{}
+	for _, r := range Rules {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// normalizeSeverity YAML 1.1 parses unquoted `off` as false and `on` as true
+func normalizeSeverity(v interface{}) string {
+	switch s := fmt.Sprint(v); s {
+	case "false":
+		return SeverityOff
+	case "true":
+		return SeverityError
+	default:
+		return s
+	}
+}
+
+// enforceSeverity map gitwok.commit.enforce mode to rule severity
+func enforceSeverity() string {
+	switch normalizeSeverity(viper.GetString("gitwok.commit.enforce")) {
+	case EnforceStrict:
+		return SeverityError
+	case EnforceOff:
+		return SeverityOff
+	default:
+		return SeverityWarn
+	}
+}
+
+// Lint check commit msg against spec and all enabled rules
+// @return violations {[]Violation} all violations found
+func (cm *CommitMsg) Lint() []Violation {
+	warnUnknownRules()
+	violations := []Violation{}
+	for _, msg := range cm.specErrors() {
+		violations = append(violations, Violation{RuleSpec, SeverityError, msg})
+	}
+
+	for _, r := range Rules {
+		severity, arg := r.Setting()
+		if severity != SeverityWarn && severity != SeverityError {
+			continue
+		}
+		if msg := r.Check(cm, arg); msg != "" {
+			violations = append(violations, Violation{r.Name, severity, msg})
+		}
+	}
+
+	return violations
+}
+
+// HasErrors check if any violation is of error severity
+func HasErrors(violations []Violation) bool {
+	for _, v := range violations {
+		if v.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// logViolations log violations by severity
+// @return ok {bool} false if any error
+func logViolations(violations []Violation) bool {
+	for _, v := range violations {
+		if v.Severity == SeverityError {
+			logger.Error(v)
+		} else {
+			logger.Warn(v)
+		}
+	}
+	return !HasErrors(violations)
+}
+
+func checkEnum(errMsg, val string, options []string) string {
+	if val == "" || len(options) == 0 || containsStr(options, val) {
+		return ""
+	}
+	return enumMsg(errMsg, val, options)
+}
+
+func maxLineLength(s string) int {
+	max := 0
+	for _, line := range strings.Split(s, "\n") {
+		if n := len([]rune(line)); n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+var casePatterns = map[string]*regexp.Regexp{
+	CaseCamel:  regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+	CasePascal: regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`),
+	CaseKebab:  regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`),
+	CaseSnake:  regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`),
+}
+
+// MatchCase check if string is in the named case
+func MatchCase(s, name string) bool {
+	switch name {
+	case CaseLower:
+		return s == strings.ToLower(s)
+	case CaseUpper:
+		return s == strings.ToUpper(s)
+	case CaseSentence:
+		for _, c := range s {
+			return !unicode.IsLower(c)
+		}
+		return true
+	case CaseStart:
+		for _, word := range strings.Fields(s) {
+			if !MatchCase(word, CaseSentence) {
+				return false
+			}
+		}
+		return true
+	default:
+		if re, ok := casePatterns[name]; ok {
+			return re.MatchString(s)
+		}
+		return true
+	}
+}
+
+func matchCases(s string, names []string) bool {
+	for _, name := range names {
+		if MatchCase(s, name) {
+			return true
+		}
+	}
+	return len(names) == 0
+}
+
+func argInt(arg interface{}) int {
+	if n, err := strconv.Atoi(fmt.Sprint(arg)); err == nil {
+		return n
+	}
+	return 0
+}
+
+func argStr(arg interface{}) string {
+	if arg == nil {
+		return ""
+	}
+	return fmt.Sprint(arg)
+}
+
+func argStrs(arg interface{}) []string {
+	switch v := arg.(type) {
+	case nil:
+		return []string{}
+	case []string:
+		return v
+	case []interface{}:
+		strs := []string{}
+		for _, s := range v {
+			strs = append(strs, fmt.Sprint(s))
+		}
+		return strs
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func lintRules(cm *CommitMsg) []string {
+	rules := []string{}
+	for _, v := range cm.Lint() {
+		rules = append(rules, v.Rule+":"+v.Severity)
+	}
+	return rules
+}
+
+func TestRuleSetting(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	r := FindRule("header-max-length")
+	if severity, arg := r.Setting(); severity != SeverityWarn || argInt(arg) != 100 {
+		t.Errorf("Rule default setting failed, got: %s, %v", severity, arg)
+	}
+
+	viper.Set("gitwok.rules.header-max-length", "error")
+	if severity, arg := r.Setting(); severity != SeverityError || argInt(arg) != 100 {
+		t.Errorf("Rule severity setting failed, got: %s, %v", severity, arg)
+	}
+
+	viper.Set("gitwok.rules.header-max-length", []interface{}{"warn", 72})
+	if severity, arg := r.Setting(); severity != SeverityWarn || argInt(arg) != 72 {
+		t.Errorf("Rule severity and arg setting failed, got: %s, %v", severity, arg)
+	}
+
+	// unquoted `off` in yaml is read as false
+	viper.Set("gitwok.rules.header-max-length", false)
+	if severity, _ := r.Setting(); severity != SeverityOff {
+		t.Errorf("Rule severity of false failed, expected: %s, got: %s", SeverityOff, severity)
+	}
+
+	viper.Set("gitwok.commit.enforce", false)
+	if severity, _ := FindRule("type-enum").Setting(); severity != SeverityOff {
+		t.Errorf("Rule enforce setting of false failed, expected: %s, got: %s", SeverityOff, severity)
+	}
+
+	// enum rules follow enforce mode unless set explicitly
+	viper.Set("gitwok.commit.enforce", EnforceStrict)
+	if severity, _ := FindRule("type-enum").Setting(); severity != SeverityError {
+		t.Errorf("Rule enforce setting failed, expected: %s, got: %s", SeverityError, severity)
+	}
+
+	// invalid severity and argument fall back to rule defaults
+	viper.Set("gitwok.rules.header-max-length", []interface{}{"eror", "72c"})
+	if severity, arg := r.Setting(); severity != SeverityWarn || argInt(arg) != 100 {
+		t.Errorf("Rule invalid setting fallback failed, got: %s, %v", severity, arg)
+	}
+}
+
+func TestScopeEnumScalarArg(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	viper.Set("gitwok.rules.scope-enum", []interface{}{"error", "api"})
+	if got := lintRules(makeCommitMsg("feat", "web", false, "desc", "", []string{})); !CompareStrSlices(got, []string{"scope-enum:" + SeverityError}) {
+		t.Errorf("Scope enum of scalar arg failed, got: %v", got)
+	}
+}
+
+func TestCommitMsgLint(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	viper.Set("gitwok.commit.scope", []string{"api", "web"})
+	defer viper.Reset()
+
+	if got := lintRules(makeCommitMsg("feat", "api", false, "desc", "", []string{})); len(got) != 0 {
+		t.Errorf("Lint failed, expected no violation, got: %v", got)
+	}
+
+	// all violations are returned at once
+	msg := makeCommitMsg("Feture", "nonsense", false, "Desc.", "", []string{"no separator"})
+	expected := []string{
+		RuleSpec + ":" + SeverityError,
+		"type-case:" + SeverityWarn,
+		"type-enum:" + SeverityWarn,
+		"scope-enum:" + SeverityWarn,
+		"subject-full-stop:" + SeverityWarn,
+	}
+	if got := lintRules(msg); !CompareStrSlices(got, expected) {
+		t.Errorf("Lint failed, expected: %v, got: %v", expected, got)
+	}
+
+	viper.Set("gitwok.rules.subject-case", []interface{}{"error", []interface{}{CaseLower, CaseSentence}})
+	viper.Set("gitwok.rules.header-max-length", []interface{}{"error", 9})
+	if got := lintRules(makeCommitMsg("feat", "", false, "Desc", "", []string{})); !CompareStrSlices(got, []string{"header-max-length:error"}) {
+		t.Errorf("Lint configured rules failed, got: %v", got)
+	}
+
+	viper.Set("gitwok.rules.header-max-length", SeverityOff)
+	if vs := makeCommitMsg("feture", "", false, "desc", "", []string{}).Lint(); len(vs) != 1 || !strings.Contains(vs[0].Message, `did you mean "feat"`) {
+		t.Errorf("Lint type-enum suggestion failed, got: %v", vs)
+	}
+}
+
+func TestMatchCase(t *testing.T) {
+	var tests = []TestBool{
+		{MatchCase("feat", CaseLower), true, "lower-case"},
+		{MatchCase("Feat", CaseLower), false, "lower-case"},
+		{MatchCase("FEAT", CaseUpper), true, "upper-case"},
+		{MatchCase("Add login", CaseSentence), true, "sentence-case"},
+		{MatchCase("add login", CaseSentence), false, "sentence-case"},
+		{MatchCase("Add Login", CaseStart), true, "start-case"},
+		{MatchCase("addLogin", CaseCamel), true, "camel-case"},
+		{MatchCase("AddLogin", CasePascal), true, "pascal-case"},
+		{MatchCase("add-login", CaseKebab), true, "kebab-case"},
+		{MatchCase("add_login", CaseSnake), true, "snake-case"},
+		{MatchCase("add_login", CaseKebab), false, "kebab-case"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("MatchCase with %s failed, expected: %t, got: %t", test.msg, test.expected, test.got)
+		}
+	}
+}
+
+func TestBreakingChangeFooterRule(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	viper.Set("gitwok.rules.breaking-change-footer", SeverityError)
+	defer viper.Reset()
+
+	var tests = []struct {
+		cm    *CommitMsg
+		valid bool
+	}{
+		{makeCommitMsg("feat", "", false, "desc", "", []string{}), true},
+		{makeCommitMsg("feat", "", true, "desc", "", []string{"BREAKING CHANGE: removed flag"}), true},
+		{makeCommitMsg("feat", "", true, "desc", "", []string{"BREAKING-CHANGE: removed flag"}), true},
+		{makeCommitMsg("feat", "", true, "desc", "", []string{}), false},
+		{makeCommitMsg("feat", "", false, "desc", "", []string{"BREAKING CHANGE: removed flag"}), false},
+	}
+
+	for _, test := range tests {
+		if vs := test.cm.Lint(); (len(vs) == 0) != test.valid {
+			t.Errorf("breaking-change-footer with %q failed, expected valid: %t, got: %v", test.cm.ToString(), test.valid, vs)
+		}
+	}
+}
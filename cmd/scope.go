@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/spf13/viper"
+)
+
+// ScopeDef scope definition in config, nestable as sub scopes
+// Accepted forms of each item in gitwok.commit.scope:
+//   - api                    # plain scope
+//   - api: [auth, billing]   # scope with sub scopes
+//   - name: api              # scope with path globs and sub scopes
+//     paths: [api/**]          # in long form
+//     scope: [auth, billing]
+type ScopeDef struct {
+	Name   string
+	Paths  []string
+	Scopes []ScopeDef
+}
+
+// ParseScopeDefs parse raw config value of scope definitions
+func ParseScopeDefs(raw interface{}) []ScopeDef {
+	defs := []ScopeDef{}
+
+	items, ok := raw.([]interface{})
+	if !ok {
+		if strs, ok := raw.([]string); ok {
+			for _, s := range strs {
+				defs = append(defs, ScopeDef{Name: s})
+			}
+		}
+		return defs
+	}
+
+	for _, item := range items {
+		switch v := item.(type) {
+		case map[interface{}]interface{}:
+			m := map[string]interface{}{}
+			for k, val := range v {
+				m[fmt.Sprint(k)] = val
+			}
+			defs = append(defs, parseScopeMap(m)...)
+		case map[string]interface{}:
+			defs = append(defs, parseScopeMap(v)...)
+		case nil:
+		default:
+			defs = append(defs, ScopeDef{Name: fmt.Sprint(v)})
+		}
+	}
+
+	return defs
+}
+
+func parseScopeMap(m map[string]interface{}) []ScopeDef {
+	if name, ok := m["name"]; ok {
+		return []ScopeDef{{
+			Name:   fmt.Sprint(name),
+			Paths:  argStrs(m["paths"]),
+			Scopes: ParseScopeDefs(m["scope"]),
+		}}
+	}
+
+	// shorthand `name: [sub scopes]`
+	names := []string{}
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	defs := []ScopeDef{}
+	for _, name := range names {
+		defs = append(defs, ScopeDef{Name: name, Scopes: ParseScopeDefs(m[name])})
+	}
+	return defs
+}
+
+// ConfigScopeDefs scope definitions of gitwok.commit.scope
+func ConfigScopeDefs() []ScopeDef {
+	return ParseScopeDefs(viper.Get("gitwok.commit.scope"))
+}
+
+// FlattenScopes list scope definitions with sub scopes joined
+// by subscope delimiter, i.e. `api`, `api/auth`, `web`
+func FlattenScopes(defs []ScopeDef) []string {
+	sep := viper.GetString("gitwok.commit.delimiter.subscope")
+	scopes := []string{}
+	for _, def := range defs {
+		scopes = append(scopes, def.Name)
+		for _, sub := range FlattenScopes(def.Scopes) {
+			scopes = append(scopes, def.Name+sep+sub)
+		}
+	}
+	return scopes
+}
+
+// ScopeOptions flattened scope options from config
+func ScopeOptions() []string {
+	return FlattenScopes(ConfigScopeDefs())
+}
+
+// SplitScopes split multiple scopes by scope delimiter
+func SplitScopes(scope string) []string {
+	scopes := []string{}
+	for _, s := range strings.Split(scope, viper.GetString("gitwok.commit.delimiter.scope")) {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// JoinScopes join multiple scopes by scope delimiter
+func JoinScopes(scopes []string) string {
+	return strings.Join(scopes, viper.GetString("gitwok.commit.delimiter.scope"))
+}
+
+// hasScopePaths check if any scope definition declares path globs
+func hasScopePaths(defs []ScopeDef) bool {
+	for _, def := range defs {
+		if len(def.Paths) > 0 || hasScopePaths(def.Scopes) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchScope return flattened name of the deepest scope
+// matching the filepath, "" if none
+func matchScope(defs []ScopeDef, fp string) string {
+	sep := viper.GetString("gitwok.commit.delimiter.subscope")
+	for _, def := range defs {
+		if sub := matchScope(def.Scopes, fp); sub != "" {
+			return def.Name + sep + sub
+		}
+		if MatchAnyGlob(def.Paths, fp) {
+			return def.Name
+		}
+	}
+	return ""
+}
+
+// ScopesOfFiles find scopes touched by filepaths, ordered as scope options
+func ScopesOfFiles(defs []ScopeDef, filepaths []string) []string {
+	touched := map[string]bool{}
+	for _, fp := range filepaths {
+		if scope := matchScope(defs, fp); scope != "" {
+			touched[scope] = true
+		}
+	}
+
+	scopes := []string{}
+	for _, scope := range FlattenScopes(defs) {
+		if touched[scope] {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// topScopes unique top level scopes of flattened scopes
+func topScopes(scopes []string) []string {
+	sep := viper.GetString("gitwok.commit.delimiter.subscope")
+	tops := []string{}
+	for _, scope := range scopes {
+		if top := strings.SplitN(scope, sep, 2)[0]; !containsStr(tops, top) {
+			tops = append(tops, top)
+		}
+	}
+	return tops
+}
+
+// suggestScopes scopes touched by staged files if any scope declares
+// path globs, warn if staged files span multiple top level scopes
+func suggestScopes(git *Git) []string {
+	defs := ConfigScopeDefs()
+	if !hasScopePaths(defs) {
+		return []string{}
+	}
+
+	scopes := ScopesOfFiles(defs, git.StagedFiles())
+	if tops := topScopes(scopes); len(tops) > 1 {
+		logger.Warn(fmt.Sprintf("Staged files span scopes %s, consider separate commits", strings.Join(tops, ", ")))
+	}
+	logger.Verbose("Scopes of staged files:", scopes)
+
+	return scopes
+}
+
+// CommitScopes helper struct for separate survey with custom Setter
+type CommitScopes struct {
+	Scope string `survey:"scope"`
+}
+
+// WriteAnswer implements Settable interface of CommitScopes for survey
+// assign input, selected or multi selected scopes as scope string
+func (cs *CommitScopes) WriteAnswer(name string, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		cs.Scope = v
+	case core.OptionAnswer:
+		cs.Scope = v.Value
+	case []core.OptionAnswer:
+		scopes := []string{}
+		for _, opt := range v {
+			scopes = append(scopes, opt.Value)
+		}
+		cs.Scope = JoinScopes(scopes)
+	default:
+		return fmt.Errorf("Write %s error, got: %v", name, value)
+	}
+	return nil
+}
+
+// scopeQuestion input if no options configured, multi select
+// if enabled by gitwok.commit.prompt.multiscope, otherwise select,
+// with suggested scopes pre-selected
+func scopeQuestion(options []string, suggested []string) *survey.Question {
+	if len(options) == 0 {
+		if len(suggested) == 0 {
+			return cmtScopeInput
+		}
+		return &survey.Question{
+			Name: "scope",
+			Prompt: &survey.Input{
+				Message: cmtScopeInput.Prompt.(*survey.Input).Message,
+				Default: JoinScopes(suggested),
+			},
+			Transform: cmtScopeInput.Transform,
+		}
+	}
+
+	if viper.GetBool("gitwok.commit.prompt.multiscope") {
+		return &survey.Question{
+			Name: "scope",
+			Prompt: &survey.MultiSelect{
+				Message: "Choose commit scopes:",
+				Options: options,
+				Default: suggested,
+			},
+		}
+	}
+
+	var dflt = options[0]
+	if len(suggested) > 0 {
+		dflt = suggested[0]
+	}
+	return &survey.Question{
+		Name: "scope",
+		Prompt: &survey.Select{
+			Message: "Choose commit scope:",
+			Options: options,
+			Default: dflt,
+		},
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/spf13/viper"
+)
+
+func TestParseScopeDefs(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	raw := []interface{}{
+		"web",
+		map[interface{}]interface{}{"api": []interface{}{"auth", "billing"}},
+		map[string]interface{}{"name": "lib", "scope": []interface{}{map[string]interface{}{"util": []interface{}{"log"}}}},
+		nil,
+	}
+
+	expected := []string{"web", "api", "api/auth", "api/billing", "lib", "lib/util", "lib/util/log"}
+	if got := FlattenScopes(ParseScopeDefs(raw)); !CompareStrSlices(got, expected) {
+		t.Errorf("ParseScopeDefs failed, expected: %v, got: %v", expected, got)
+	}
+
+	if got := FlattenScopes(ParseScopeDefs([]string{"a", "b"})); !CompareStrSlices(got, []string{"a", "b"}) {
+		t.Errorf("ParseScopeDefs string slice failed, got: %v", got)
+	}
+
+	if got := ScopeOptions(); len(got) != 0 {
+		t.Errorf("ScopeOptions default failed, expected empty, got: %v", got)
+	}
+}
+
+func TestSplitScopes(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	if got, expected := SplitScopes(" api, web/auth ,"), []string{"api", "web/auth"}; !CompareStrSlices(got, expected) {
+		t.Errorf("SplitScopes failed, expected: %v, got: %v", expected, got)
+	}
+
+	if got, expected := JoinScopes([]string{"api", "web"}), "api,web"; got != expected {
+		t.Errorf("JoinScopes failed, expected: %q, got: %q", expected, got)
+	}
+
+	viper.Set("gitwok.commit.delimiter.scope", "|")
+	if got, expected := SplitScopes("api|web"), []string{"api", "web"}; !CompareStrSlices(got, expected) {
+		t.Errorf("SplitScopes with custom delimiter failed, expected: %v, got: %v", expected, got)
+	}
+}
+
+func TestScopesWriteAnswer(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	var cs CommitScopes
+	if err := cs.WriteAnswer("scope", 1); err == nil {
+		t.Error("Write answer to custom scopes struct failed, expected error of non string input")
+	}
+
+	if err := cs.WriteAnswer("scope", "api"); err != nil || cs.Scope != "api" {
+		t.Errorf("Write input answer to custom scopes struct failed, got: %q, %v", cs.Scope, err)
+	}
+
+	if err := cs.WriteAnswer("scope", core.OptionAnswer{Value: "web", Index: 1}); err != nil || cs.Scope != "web" {
+		t.Errorf("Write select answer to custom scopes struct failed, got: %q, %v", cs.Scope, err)
+	}
+
+	answer := []core.OptionAnswer{{Value: "api", Index: 0}, {Value: "web/auth", Index: 2}}
+	if err := cs.WriteAnswer("scope", answer); err != nil || cs.Scope != "api,web/auth" {
+		t.Errorf("Write multi select answer to custom scopes struct failed, got: %q, %v", cs.Scope, err)
+	}
+}
+
+func TestScopeEnumRule(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	viper.Set("gitwok.commit.scope", []interface{}{"web", map[string]interface{}{"api": []interface{}{"auth"}}})
+	viper.Set("gitwok.commit.enforce", EnforceStrict)
+	defer viper.Reset()
+
+	for _, scope := range []string{"api,web", "api/auth", "web, api/auth"} {
+		if vs := makeCommitMsg("feat", scope, false, "desc", "", []string{}).Lint(); len(vs) != 0 {
+			t.Errorf("scope-enum with %q failed, expected valid, got: %v", scope, vs)
+		}
+	}
+
+	for _, scope := range []string{"api,nonsense", "api/billing", "auth"} {
+		if vs := makeCommitMsg("feat", scope, false, "desc", "", []string{}).Lint(); len(vs) != 1 || vs[0].Rule != "scope-enum" {
+			t.Errorf("scope-enum with %q failed, expected invalid, got: %v", scope, vs)
+		}
+	}
+}
+
+func TestScopesOfFiles(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	defs := ParseScopeDefs([]interface{}{
+		map[string]interface{}{"name": "docs", "paths": []interface{}{"docs/**", "*.md"}},
+		map[string]interface{}{
+			"name":  "api",
+			"paths": []interface{}{"api/**"},
+			"scope": []interface{}{map[string]interface{}{"name": "auth", "paths": []interface{}{"api/auth/**"}}},
+		},
+		"web",
+	})
+
+	if !hasScopePaths(defs) || hasScopePaths(ParseScopeDefs([]interface{}{"web"})) {
+		t.Error("hasScopePaths failed")
+	}
+
+	var tests = []struct {
+		files    []string
+		expected []string
+	}{
+		{[]string{}, []string{}},
+		{[]string{"web/index.html"}, []string{}},
+		{[]string{"README.md", "docs/images/add.png"}, []string{"docs"}},
+		{[]string{"api/auth/token.go"}, []string{"api/auth"}},
+		{[]string{"api/auth/token.go", "api/server.go", "README.md"}, []string{"docs", "api", "api/auth"}},
+	}
+
+	for _, test := range tests {
+		if got := ScopesOfFiles(defs, test.files); !CompareStrSlices(got, test.expected) {
+			t.Errorf("ScopesOfFiles with %v failed, expected: %v, got: %v", test.files, test.expected, got)
+		}
+	}
+
+	if got, expected := topScopes([]string{"docs", "api", "api/auth"}), []string{"docs", "api"}; !CompareStrSlices(got, expected) {
+		t.Errorf("topScopes failed, expected: %v, got: %v", expected, got)
+	}
+}
+
+func TestScopeQuestion(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	if q := scopeQuestion([]string{}, []string{}); q != cmtScopeInput {
+		t.Error("scopeQuestion failed, expected input without options")
+	}
+
+	if q := scopeQuestion([]string{}, []string{"api", "web"}); q.Prompt.(*survey.Input).Default != "api,web" {
+		t.Error("scopeQuestion failed, expected input with suggested default")
+	}
+
+	if q := scopeQuestion([]string{"api", "web"}, []string{"web"}); q.Prompt.(*survey.Select).Default != "web" {
+		t.Error("scopeQuestion failed, expected select with suggested default")
+	}
+
+	viper.Set("gitwok.commit.prompt.multiscope", true)
+	if q := scopeQuestion([]string{"api", "web"}, []string{"web"}); !CompareStrSlices(q.Prompt.(*survey.MultiSelect).Default.([]string), []string{"web"}) {
+		t.Error("scopeQuestion failed, expected multi select with suggested defaults")
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// FTokenSignedOff DCO sign-off footer token
+const FTokenSignedOff = "Signed-off-by"
+
+// AuthorIdent `Name <email>` of the commit author by `git var GIT_AUTHOR_IDENT`
+func (git *Git) AuthorIdent() string {
+	ident, err := git.run("var", "GIT_AUTHOR_IDENT")
+	if err != nil {
+		return ""
+	}
+	// strip trailing `<timestamp> <timezone>`
+	if i := strings.LastIndex(ident, ">"); i >= 0 {
+		ident = ident[:i+1]
+	}
+	return ident
+}
+
+// SignOff append Signed-off-by footer of ident if not signed off yet
+func (cm *CommitMsg) SignOff(ident string) {
+	if footer := MakeFooter(FTokenSignedOff, ident); !containsStr(cm.Footers, footer) {
+		cm.Footers = append(cm.Footers, footer)
+	}
+}
+
+// SignedOffBy identities of Signed-off-by footers
+func (cm *CommitMsg) SignedOffBy() []string {
+	idents := []string{}
+	for _, f := range cm.Footers {
+		if token, sep, val := ParseFooter(f); token == FTokenSignedOff && sep == FSepColonSpace {
+			idents = append(idents, strings.TrimSpace(val))
+		}
+	}
+	return idents
+}
+
+// SignArgs git commit args of signing by gitwok.commit.sign, `-S[<key>]`
+func SignArgs() []string {
+	if !viper.GetBool("gitwok.commit.sign.enabled") {
+		return []string{}
+	}
+	return []string{"-S" + viper.GetString("gitwok.commit.sign.key")}
+}
+
+// SignConfigs git config of signing format by gitwok.commit.sign.format
+func SignConfigs() []string {
+	if format := viper.GetString("gitwok.commit.sign.format"); format != "" && viper.GetBool("gitwok.commit.sign.enabled") {
+		return []string{fmt.Sprintf("gpg.format=%s", format)}
+	}
+	return []string{}
+}
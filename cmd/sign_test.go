@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSignOff(t *testing.T) {
+	cm := makeCommitMsg("fix", "", false, "desc", "", []string{"Refs: #1"})
+	cm.SignOff("Jane Doe <jane@example.com>")
+	cm.SignOff("Jane Doe <jane@example.com>")
+
+	expected := []string{"Refs: #1", "Signed-off-by: Jane Doe <jane@example.com>"}
+	if !CompareStrSlices(cm.Footers, expected) {
+		t.Errorf("SignOff failed, expected: %v, got: %v", expected, cm.Footers)
+	}
+
+	if got := cm.SignedOffBy(); !CompareStrSlices(got, []string{"Jane Doe <jane@example.com>"}) {
+		t.Errorf("SignedOffBy failed, got: %v", got)
+	}
+}
+
+func TestSignArgs(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	if args, configs := SignArgs(), SignConfigs(); len(args) != 0 || len(configs) != 0 {
+		t.Errorf("SignArgs default failed, expected none, got: %v, %v", args, configs)
+	}
+
+	viper.Set("gitwok.commit.sign.enabled", true)
+	if args := SignArgs(); !CompareStrSlices(args, []string{"-S"}) {
+		t.Errorf("SignArgs failed, expected: [-S], got: %v", args)
+	}
+
+	viper.Set("gitwok.commit.sign.key", "ABCD1234")
+	viper.Set("gitwok.commit.sign.format", "ssh")
+	if args := SignArgs(); !CompareStrSlices(args, []string{"-SABCD1234"}) {
+		t.Errorf("SignArgs with key failed, expected: [-SABCD1234], got: %v", args)
+	}
+
+	git := &Git{configs: SignConfigs()}
+	if got, expected := git.prependConfigs([]string{"commit"}), []string{"-c", "gpg.format=ssh", "commit"}; !CompareStrSlices(got, expected) {
+		t.Errorf("prependConfigs failed, expected: %v, got: %v", expected, got)
+	}
+}
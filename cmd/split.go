@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+// FileGroup changed files grouped by matched scope
+type FileGroup struct {
+	Scope string   `json:"scope"` // "" for files matching no scope
+	Files []string `json:"files"`
+}
+
+// GroupByScope group filepaths by the deepest matching scope, ordered
+// as scope options, files matching no scope are grouped last
+func GroupByScope(defs []ScopeDef, filepaths []string) []FileGroup {
+	dict := map[string][]string{}
+	for _, fp := range filepaths {
+		scope := matchScope(defs, fp)
+		dict[scope] = append(dict[scope], fp)
+	}
+
+	groups := []FileGroup{}
+	for _, scope := range append(FlattenScopes(defs), "") {
+		if files, ok := dict[scope]; ok {
+			groups = append(groups, FileGroup{scope, files})
+		}
+	}
+	return groups
+}
+
+// WithRenamed add original paths of renamed files to the group of the new path,
+// so that the deletion is committed together with the addition
+func WithRenamed(groups []FileGroup, renames map[string]string) []FileGroup {
+	for i, group := range groups {
+		for _, fp := range group.Files {
+			if orig, ok := renames[fp]; ok && !containsStr(groups[i].Files, orig) {
+				groups[i].Files = append(groups[i].Files, orig)
+			}
+		}
+	}
+	return groups
+}
+
+// SplitSession state of a split session for summary and rollback
+type SplitSession struct {
+	git          *Git
+	origHead     string        // HEAD before session
+	origTree     string        // index tree before session
+	commits      []SplitCommit // commits made
+	skipped      []string      // scopes of groups skipped
+	skippedFiles []string      // files of groups skipped, index restored after session
+	guarded      bool          // branch checked by Guard before the first commit
+}
+
+// ParseNameStatus parse `git diff --name-status -z` output
+// @return files {[]string} changed paths, new paths of renames and copies
+// @return renames {map[string]string} original path by new path of renames
+func ParseNameStatus(out string) ([]string, map[string]string) {
+	files := []string{}
+	renames := map[string]string{}
+	entries := splitNul(out)
+	for i := 0; i < len(entries); i++ {
+		status := entries[i]
+		switch {
+		case strings.HasPrefix(status, "R") && i+2 < len(entries):
+			renames[entries[i+2]] = entries[i+1]
+			files = append(files, entries[i+2])
+			i += 2
+		case strings.HasPrefix(status, "C") && i+2 < len(entries):
+			files = append(files, entries[i+2])
+			i += 2
+		case i+1 < len(entries):
+			files = append(files, entries[i+1])
+			i++
+		}
+	}
+	return files, renames
+}
+
+// changedFiles staged, unstaged and untracked filepaths, deduplicated, and
+// original paths of staged renames, read NUL separated so that paths are not quoted
+func changedFiles(git *Git) ([]string, map[string]string) {
+	files, renames := ParseNameStatus(mustStr(git.run("diff", "--cached", "--name-status", "-M", "-z")))
+	unstaged := splitNul(mustStr(git.run("diff", "--name-only", "-z")))
+	unstaged = append(unstaged, splitNul(mustStr(git.run("ls-files", "--others", "--exclude-standard", "--full-name", "-z")))...)
+	for _, fp := range unstaged {
+		if !containsStr(files, fp) {
+			files = append(files, fp)
+		}
+	}
+	return files, renames
+}
+
+// commitGroup stage files of group, prompt and commit
+// @return committed {bool} false if skipped
+func (s *SplitSession) commitGroup(group FileGroup) (bool, error) {
+	label := group.Scope
+	if label == "" {
+		label = "(no scope)"
+	}
+
+	logger.Info(fmt.Sprintf("%s:\n  %s", label, strings.Join(group.Files, "\n  ")))
+	confirm := false
+	if err := survey.AskOne(&survey.Confirm{
+		Message: fmt.Sprintf("Commit %d files of %s?", len(group.Files), label),
+		Default: true,
+	}, &confirm, askOpts()...); err != nil || !confirm {
+		if err == nil {
+			s.skipped = append(s.skipped, label)
+			s.skippedFiles = append(s.skippedFiles, group.Files...)
+		}
+		return false, err
+	}
+
+	if _, err := s.git.run(append([]string{"add", "-A", "--"}, group.Files...)...); err != nil {
+		return false, err
+	}
+
+	var cm CommitMsg
+	if err := cm.Ask(); err != nil {
+		return false, err
+	}
+	cm.Complete(s.git)
+	msg, err := cm.Render(s.git)
+	if err != nil {
+		return false, err
+	}
+	if ok := logViolations(LintMsg(msg)); !ok {
+		return false, fmt.Errorf("commit message of %s is invalid", label)
+	}
+	if !s.guarded {
+		if err := cm.Guard(s.git); err != nil {
+			return false, err
+		}
+		s.guarded = true
+	}
+
+	if _, err := s.git.run(append([]string{"commit", "-m", msg}, SignArgs()...)...); err != nil {
+		return false, err
+	}
+
+	sha, err := s.git.run("rev-parse", "--short", "HEAD")
+	if err != nil {
+		return false, err
+	}
+	s.commits = append(s.commits, SplitCommit{sha, cm.Header()})
+
+	return true, nil
+}
+
+// rollback undo commits made in session and restore index,
+// working tree is kept intact
+func (s *SplitSession) rollback() {
+	if _, err := s.git.run("reset", "-q", "--soft", s.origHead); err != nil {
+		logger.Error(err)
+	}
+	if _, err := s.git.run("read-tree", s.origTree); err != nil {
+		logger.Error(err)
+	}
+	logger.Warn(fmt.Sprintf("Rolled back %d commits and restored index", len(s.commits)))
+}
+
+// restoreSkipped restore index entries of skipped files as before session
+func (s *SplitSession) restoreSkipped() error {
+	if len(s.skippedFiles) == 0 {
+		return nil
+	}
+	_, err := s.git.run(append([]string{"reset", "-q", s.origTree, "--"}, s.skippedFiles...)...)
+	return err
+}
+
+func (s *SplitSession) summary(groups []FileGroup) {
+	if isJSONOutput() {
+		printJSON(SplitOutput{groups, s.commits, s.skipped})
+		return
+	}
+
+	logger.Info(fmt.Sprintf("Made %d commits:", len(s.commits)))
+	for _, c := range s.commits {
+		logger.Info("  " + c.Sha + " " + c.Header)
+	}
+	if len(s.skipped) > 0 {
+		logger.Info("Skipped changes of:", strings.Join(s.skipped, ", "))
+	}
+}
+
+var splitCmd = &cobra.Command{
+	Use:   "split",
+	Short: "split changes into one commit per scope",
+	Long:  "group staged and unstaged changes by scope paths and make one conventional commit per scope",
+	Run: func(cmd *cobra.Command, args []string) {
+		var git = &Git{
+			verbose: false,
+			dryRun:  mustBool(cmd.Flags().GetBool("dry-run")),
+			configs: SignConfigs(),
+		}
+
+		// path overrides would differ by group, only branch overrides apply
+		applyBranchOverrides(git.CurrentBranch())
+
+		defs := ConfigScopeDefs()
+		if !hasScopePaths(defs) {
+			logger.Fatal("No scope paths configured, see gitwok.commit.scope")
+		}
+
+		files, renames := changedFiles(git)
+		groups := WithRenamed(GroupByScope(defs, files), renames)
+		if len(groups) == 0 {
+			logger.Info("No changes to split")
+			if isJSONOutput() {
+				printJSON(SplitOutput{groups, []SplitCommit{}, []string{}})
+			}
+			return
+		}
+
+		if git.dryRun {
+			if isJSONOutput() {
+				printJSON(SplitOutput{groups, []SplitCommit{}, []string{}})
+				return
+			}
+			for _, group := range groups {
+				logger.Info(fmt.Sprintf("%s: %s", group.Scope, strings.Join(group.Files, ", ")))
+			}
+			return
+		}
+
+		origHead, err := git.run("rev-parse", "--verify", "HEAD")
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("split requires an existing commit: %v", err))
+		}
+		s := &SplitSession{git: git, origHead: origHead, origTree: mustStr(git.run("write-tree")), commits: []SplitCommit{}, skipped: []string{}}
+
+		// unstage all, each group is staged before its commit
+		mustStr(git.run("reset", "-q"))
+
+		for _, group := range groups {
+			if _, err := s.commitGroup(group); err != nil {
+				logger.Error(err)
+				s.rollback()
+				os.Exit(1)
+			}
+		}
+		if err := s.restoreSkipped(); err != nil {
+			logger.Error(err)
+		}
+
+		s.summary(groups)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(splitCmd)
+}
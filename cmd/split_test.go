@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestGroupByScope(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	defs := ParseScopeDefs([]interface{}{
+		map[string]interface{}{"name": "docs", "paths": []interface{}{"docs/**", "*.md"}},
+		map[string]interface{}{"name": "cmd", "paths": []interface{}{"cmd/**"}},
+	})
+
+	groups := GroupByScope(defs, []string{"cmd/add.go", "main.go", "README.md", "cmd/add_test.go", "docs/images/add.png"})
+	expected := []FileGroup{
+		{"docs", []string{"README.md", "docs/images/add.png"}},
+		{"cmd", []string{"cmd/add.go", "cmd/add_test.go"}},
+		{"", []string{"main.go"}},
+	}
+
+	if len(groups) != len(expected) {
+		t.Fatalf("GroupByScope failed, expected: %v, got: %v", expected, groups)
+	}
+	for i, group := range groups {
+		if group.Scope != expected[i].Scope || !CompareStrSlices(group.Files, expected[i].Files) {
+			t.Errorf("GroupByScope failed, expected: %v, got: %v", expected[i], group)
+		}
+	}
+
+	if groups := GroupByScope(defs, []string{}); len(groups) != 0 {
+		t.Errorf("GroupByScope failed, expected no group, got: %v", groups)
+	}
+}
+
+func TestParseNameStatus(t *testing.T) {
+	out := "M\x00cmd/add.go\x00R100\x00docs/old.md\x00docs/new.md\x00C75\x00a.go\x00b.go\x00D\x00main.go\x00"
+	files, renames := ParseNameStatus(out)
+	if expected := []string{"cmd/add.go", "docs/new.md", "b.go", "main.go"}; !CompareStrSlices(files, expected) {
+		t.Errorf("ParseNameStatus files failed, expected: %v, got: %v", expected, files)
+	}
+	if len(renames) != 1 || renames["docs/new.md"] != "docs/old.md" {
+		t.Errorf("ParseNameStatus renames failed, got: %v", renames)
+	}
+}
+
+func TestWithRenamed(t *testing.T) {
+	groups := WithRenamed([]FileGroup{
+		{"docs", []string{"docs/new.md"}},
+		{"", []string{"main.go"}},
+	}, map[string]string{"docs/new.md": "old.md"})
+
+	if !CompareStrSlices(groups[0].Files, []string{"docs/new.md", "old.md"}) || !CompareStrSlices(groups[1].Files, []string{"main.go"}) {
+		t.Errorf("WithRenamed failed, expected original path in group of new path, got: %v", groups)
+	}
+}
+
+func TestChangedFilesUnquoted(t *testing.T) {
+	git, write, cleanup := testRepo(t)
+	defer cleanup()
+
+	write("api/a b.go", "a")
+	git("add", "-A")
+	git("commit", "-q", "-m", "chore: init")
+	write("api/a b.go", "changed")
+	write("api/ü.go", "u")
+	write("web/c d.go", "c")
+	git("add", "web/c d.go")
+
+	files, _ := changedFiles(&Git{})
+	if expected := []string{"web/c d.go", "api/a b.go", "api/ü.go"}; !CompareStrSlices(files, expected) {
+		t.Errorf("Expected unquoted paths %q, got: %q", expected, files)
+	}
+}
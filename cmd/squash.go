@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ErrNoCommits no commit to squash in range
+var ErrNoCommits = errors.New("no commits to squash")
+
+// SquashMsg commit message squashing commits, oldest first:
+// the most frequent type, ties broken by release impact,
+// scopes merged, `!` if any commit breaks, each commit a bullet
+// of its header and body, footers deduplicated.
+// Description is of the oldest commit of the chosen type
+func SquashMsg(commits []LoggedCommit) (*CommitMsg, error) {
+	if len(commits) == 0 {
+		return nil, ErrNoCommits
+	}
+
+	parsed := []*CommitMsg{}
+	bullets := []string{}
+	footers := []string{}
+	scopes := []string{}
+	breaking := false
+	for _, c := range commits {
+		msg := StripComments(c.Message)
+		cm, ok := ParseCommitMsg(msg)
+		if !ok {
+			// non conventional commits are kept as bullets only
+			lines := strings.SplitN(msg, "\n", 2)
+			lines = append(lines, "")
+			bullets = append(bullets, bullet(lines[0], lines[1]))
+			continue
+		}
+
+		parsed = append(parsed, cm)
+		bullets = append(bullets, bullet(cm.Header(), cm.Body))
+		for _, s := range SplitScopes(cm.Scope) {
+			if !containsStr(scopes, s) {
+				scopes = append(scopes, s)
+			}
+		}
+		for _, f := range cm.Footers {
+			if !containsStr(footers, f) {
+				footers = append(footers, f)
+			}
+		}
+		breaking = breaking || cm.HasBrkChange || cm.HasBrkChnFooter()
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("no conventional commit to squash of %d commits", len(commits))
+	}
+
+	squashed := dominantType(parsed)
+	return &CommitMsg{
+		Type:         squashed.Type,
+		Scope:        JoinScopes(scopes),
+		HasBrkChange: breaking,
+		Description:  squashed.Description,
+		Body:         strings.Join(bullets, "\n"),
+		Footers:      footers,
+	}, nil
+}
+
+// bullet markdown list item of header, with body indented below
+func bullet(header, body string) string {
+	item := "- " + strings.TrimSpace(header)
+	if body = strings.TrimSpace(body); body != "" {
+		item += "\n  " + strings.ReplaceAll(body, "\n", "\n  ")
+	}
+	// keep blank lines of body empty
+	return strings.ReplaceAll(item, "\n  \n", "\n\n")
+}
+
+// dominantType oldest commit of the most frequent type, ties broken
+// by release impact, feat then fix, then order of type options
+func dominantType(commits []*CommitMsg) *CommitMsg {
+	counts := map[string]int{}
+	for _, cm := range commits {
+		counts[cm.Type]++
+	}
+
+	options := append([]string{"feat", "fix"}, TypeOptions()...)
+	rank := func(t string) int {
+		for i, o := range options {
+			if o == t {
+				return i
+			}
+		}
+		return len(options)
+	}
+
+	dominant := commits[0]
+	for _, cm := range commits[1:] {
+		if n, top := counts[cm.Type], counts[dominant.Type]; n > top || n == top && rank(cm.Type) < rank(dominant.Type) {
+			dominant = cm
+		}
+	}
+	return dominant
+}
+
+// SquashViolations lint squash commit msg, lines of bullets are not checked
+// by body-max-line-length, which are of bodies checked when committed
+func SquashViolations(msg string) []Violation {
+	return withoutRule(LintMsg(msg), "body-max-line-length")
+}
+
+// squashBase merge base of range `<base>..<tip>` and tip, tip defaults to HEAD
+func (git *Git) squashBase(rng string) (base, tip string, err error) {
+	parts := strings.Split(rng, "..")
+	if len(parts) != 2 || strings.HasPrefix(parts[1], ".") || parts[0] == "" {
+		return "", "", fmt.Errorf("range %q must be <base>..<tip>", rng)
+	}
+	if tip = parts[1]; tip == "" {
+		tip = "HEAD"
+	}
+	base, err = git.run("merge-base", parts[0], tip)
+	return base, tip, err
+}
+
+// applySquash soft reset to base and commit msg, HEAD is restored if commit fails
+func applySquash(git *Git, base, msg string) error {
+	head, err := git.run("rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	if git.dryRun {
+		logger.Info("git reset --soft", base)
+		logger.Info("git commit -m", msg)
+		return nil
+	}
+
+	if _, err := git.run("diff", "--cached", "--quiet"); err != nil {
+		return errors.New("staged changes would be squashed, commit or stash them first")
+	}
+	if _, err := git.run("reset", "-q", "--soft", base); err != nil {
+		return err
+	}
+	if _, err := git.run(append([]string{"commit", "-q", "-m", msg}, SignArgs()...)...); err != nil {
+		if _, rerr := git.run("reset", "-q", "--soft", head); rerr != nil {
+			logger.Error(rerr)
+		}
+		return err
+	}
+	logger.Info("Squashed into", mustStr(git.run("rev-parse", "--short", "HEAD")), Subject(msg))
+	return nil
+}
+
+// printsSquashMsg check if squash-msg is run to print the message, which may be
+// piped to `git commit -F -`, so logs must not go to stdout, see initOutput
+func printsSquashMsg() bool {
+	return squashMsgCmd.CalledAs() != "" && !mustBool(squashMsgCmd.Flags().GetBool("apply"))
+}
+
+var squashMsgCmd = &cobra.Command{
+	Use:   "squash-msg <base>..<tip>",
+	Short: "build squash commit message of range",
+	Long:  "build a conventional commit message squashing the commits of range, print it or apply it by soft reset to the merge base and commit",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var git = &Git{
+			verbose: false,
+			dryRun:  mustBool(cmd.Flags().GetBool("dry-run")),
+			configs: SignConfigs(),
+		}
+		apply := mustBool(cmd.Flags().GetBool("apply"))
+		branch := git.CurrentBranch()
+		applyBranchOverrides(branch)
+
+		if apply && IsProtected(branch) {
+			logger.Fatal(fmt.Sprintf("Branch %s is protected, its commits must not be squashed", branch))
+		}
+
+		base, tip, err := git.squashBase(args[0])
+		must(err)
+		commits, err := git.LogCommits("--reverse", base+".."+tip)
+		must(err)
+		cm, err := SquashMsg(commits)
+		must(err)
+
+		if desc := mustStr(cmd.Flags().GetString("description")); desc != "" {
+			cm.Description = strings.TrimSpace(desc)
+		}
+		// signoff and tickets of the squashing user and branch are not filled in,
+		// footers of the squashed commits are kept
+		msg, err := cm.Render(git)
+		must(err)
+		ok := logViolations(SquashViolations(msg))
+
+		if !apply {
+			// message is printed to be edited even if invalid
+			if isJSONOutput() {
+				printJSON(CommitOutput{"", msg, cm})
+			} else {
+				fmt.Print(msg)
+			}
+			if !ok {
+				os.Exit(1)
+			}
+			return
+		}
+		if !ok {
+			os.Exit(1)
+		}
+
+		if t, h := mustStr(git.run("rev-parse", tip)), mustStr(git.run("rev-parse", "HEAD")); t != h {
+			logger.Fatal(fmt.Sprintf("range tip %s is not HEAD, check it out to apply", tip))
+		}
+		must(applySquash(git, base, msg))
+		if isJSONOutput() {
+			var sha string
+			if !git.dryRun {
+				sha = mustStr(git.run("rev-parse", "HEAD"))
+			}
+			printJSON(CommitOutput{sha, msg, cm})
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(squashMsgCmd)
+
+	squashMsgCmd.Flags().Bool("apply", false, "soft reset to merge base of range and commit the message, tip must be HEAD")
+	squashMsgCmd.Flags().StringP("description", "d", "", "optional: description replacing the one of the oldest commit of chosen type")
+}
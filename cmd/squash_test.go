@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSquashMsg(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	commits := []LoggedCommit{
+		{"1111111111111111111111111111111111111111", "", "feat(api): add login endpoint\n\nAccepts a token.\n\nRefs #12"},
+		{"2222222222222222222222222222222222222222", "", "fix(web): handle expired token\n\nRefs #12\nSigned-off-by: Jane Doe <jane@example.com>"},
+		{"3333333333333333333333333333333333333333", "", "wip"},
+		{"4444444444444444444444444444444444444444", "", "feat(api)!: drop basic auth\n\nBREAKING CHANGE: basic auth is removed"},
+	}
+
+	cm, err := SquashMsg(commits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "feat(api,web)!: add login endpoint\n\n" +
+		"- feat(api): add login endpoint\n  Accepts a token.\n" +
+		"- fix(web): handle expired token\n" +
+		"- wip\n" +
+		"- feat(api)!: drop basic auth\n\n" +
+		"Refs #12\nSigned-off-by: Jane Doe <jane@example.com>\nBREAKING CHANGE: basic auth is removed\n"
+	if msg := cm.ToString(); msg != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, msg)
+	}
+
+	parsed, ok := ParseCommitMsg(cm.ToString())
+	if !ok || len(parsed.Footers) != 3 || !parsed.HasBrkChange {
+		t.Errorf("Expected squashed message to parse with 3 footers, got: %+v", parsed)
+	}
+
+	if _, err := SquashMsg([]LoggedCommit{}); err != ErrNoCommits {
+		t.Errorf("Expected ErrNoCommits, got: %v", err)
+	}
+	if _, err := SquashMsg(commits[2:3]); err == nil {
+		t.Error("Expected error of no conventional commit")
+	}
+}
+
+func TestDominantType(t *testing.T) {
+	viper.Set("gitwok.commit.type", []string{"test", "fix", "docs", "feat"})
+	defer viper.Set("gitwok.commit.type", nil)
+
+	tests := []struct {
+		types    []string
+		expected int
+	}{
+		{[]string{"docs", "fix", "fix"}, 1},
+		{[]string{"docs", "fix"}, 1},                        // tie broken by release impact
+		{[]string{"fix", "feat"}, 1},                        // tie broken by release impact
+		{[]string{"docs", "test"}, 1},                       // tie broken by type options
+		{[]string{"chore", "ci"}, 0},                        // unknown types, first seen
+		{[]string{"fix", "feat", "fix", "feat", "docs"}, 1}, // oldest of tied feat
+	}
+
+	for _, test := range tests {
+		commits := []*CommitMsg{}
+		for _, typ := range test.types {
+			commits = append(commits, &CommitMsg{Type: typ})
+		}
+		if got := dominantType(commits); got != commits[test.expected] {
+			t.Errorf("Expected commit %d of %v, got: %+v", test.expected, test.types, got)
+		}
+	}
+}
+
+func TestBullet(t *testing.T) {
+	expected := "- feat: add login\n  first line\n\n  second paragraph"
+	if got := bullet("feat: add login", "first line\n\nsecond paragraph\n"); got != expected {
+		t.Errorf("Expected %q, got: %q", expected, got)
+	}
+	if got := bullet("wip", ""); got != "- wip" {
+		t.Errorf("Expected header only, got: %q", got)
+	}
+}
+
+func TestSquashViolations(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	viper.Set("gitwok.rules.body-max-line-length", []interface{}{SeverityError, 20})
+	defer viper.Reset()
+
+	cm, err := SquashMsg([]LoggedCommit{
+		{"1111111111111111111111111111111111111111", "", "feat: add login\n\nAccepts a token of the identity provider."},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if violations := SquashViolations(cm.ToString()); len(violations) != 0 {
+		t.Errorf("Expected bullet lines not checked by body-max-line-length, got: %v", violations)
+	}
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// TaskFilesPlaceholder replaced by matched staged files in task command
+const TaskFilesPlaceholder = "{files}"
+
+// Task hook task in gitwok.hooks.<hook>.tasks
+//   - name: gofmt
+//     run: gofmt -l -w {files}
+//     glob: ["**/*.go"]
+//     restage: true
+type Task struct {
+	Name    string
+	Run     string   // shell command, {files} is replaced by matched staged files
+	Glob    []string // staged file globs, task is skipped if none matches, all files if empty
+	Restage bool     // git add matched files after run, i.e. for formatters
+}
+
+// TaskResult result of a task run
+type TaskResult struct {
+	Task     Task
+	Files    []string // matched staged files
+	Output   string   // combined stdout and stderr
+	Err      error
+	Skipped  bool
+	Duration time.Duration
+}
+
+// ParseTasks parse raw config value of hook tasks
+func ParseTasks(raw interface{}) []Task {
+	tasks := []Task{}
+	items, _ := raw.([]interface{})
+	for i, item := range items {
+		m, ok := toSettings(item)
+		if !ok {
+			continue
+		}
+
+		task := Task{
+			Name: fmt.Sprint(m["name"]),
+			Run:  argStr(m["run"]),
+			Glob: argStrs(m["glob"]),
+		}
+		task.Restage, _ = m["restage"].(bool)
+		if m["name"] == nil {
+			task.Name = fmt.Sprintf("task %d", i+1)
+		}
+		if task.Run != "" {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// HookTasks tasks and max parallel tasks of hook in gitwok.hooks.<hook>
+func HookTasks(hook string) ([]Task, int) {
+	parallel := viper.GetInt("gitwok.hooks." + hook + ".parallel")
+	if parallel < 1 {
+		parallel = 1
+	}
+	return ParseTasks(viper.Get("gitwok.hooks." + hook + ".tasks")), parallel
+}
+
+// MatchFiles staged files matching any glob of task, all if no glob
+func (t Task) MatchFiles(staged []string) []string {
+	if len(t.Glob) == 0 {
+		return staged
+	}
+	files := []string{}
+	for _, fp := range staged {
+		if MatchAnyGlob(t.Glob, fp) {
+			files = append(files, fp)
+		}
+	}
+	return files
+}
+
+// Command shell command of task with files placeholder replaced
+func (t Task) Command(files []string) string {
+	quoted := []string{}
+	for _, fp := range files {
+		quoted = append(quoted, shellQuote(fp))
+	}
+	return strings.ReplaceAll(t.Run, TaskFilesPlaceholder, strings.Join(quoted, " "))
+}
+
+// shellQuote single quote s for sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// runTask run task command by sh, skipped if no staged file matches
+func runTask(t Task, staged []string) TaskResult {
+	files := t.MatchFiles(staged)
+	if len(files) == 0 {
+		return TaskResult{Task: t, Files: files, Skipped: true}
+	}
+
+	start := time.Now()
+	out, err := exec.Command("sh", "-c", t.Command(files)).CombinedOutput()
+	return TaskResult{Task: t, Files: files, Output: string(out), Err: err, Duration: time.Since(start)}
+}
+
+// RunTasks run tasks with at most parallel tasks at a time
+// @return results {[]TaskResult} in order of tasks
+func RunTasks(tasks []Task, staged []string, parallel int) []TaskResult {
+	results := make([]TaskResult, len(tasks))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, t := range tasks {
+		wg.Add(1)
+		go func(i int, t Task) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = runTask(t, staged)
+		}(i, t)
+	}
+	wg.Wait()
+	return results
+}
+
+// PrintTaskResults print status and captured output of each task
+// @return ok {bool} false if any task failed
+func PrintTaskResults(w io.Writer, results []TaskResult) bool {
+	ok := true
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			fmt.Fprintf(w, "- %s: skipped, no staged file matches\n", r.Task.Name)
+		case r.Err != nil:
+			ok = false
+			fmt.Fprintf(w, "✗ %s: %v (%s)\n", r.Task.Name, r.Err, r.Duration.Round(time.Millisecond))
+		default:
+			fmt.Fprintf(w, "✓ %s (%s)\n", r.Task.Name, r.Duration.Round(time.Millisecond))
+		}
+		if out := strings.TrimRight(r.Output, "\n"); out != "" {
+			fmt.Fprintln(w, "  "+strings.ReplaceAll(out, "\n", "\n  "))
+		}
+	}
+	return ok
+}
+
+// restage git add files of succeeded restage tasks, files with unstaged
+// changes before the run are not restaged to keep partial staging intact
+func restage(git *Git, results []TaskResult, partial []string) error {
+	files := []string{}
+	for _, r := range results {
+		if !r.Task.Restage || r.Skipped || r.Err != nil {
+			continue
+		}
+		for _, fp := range r.Files {
+			if containsStr(partial, fp) {
+				logger.Warn(fmt.Sprintf("%s is partially staged, not restaged after %s", fp, r.Task.Name))
+			} else if !containsStr(files, fp) {
+				files = append(files, fp)
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	_, err := git.run(append([]string{"add", "--"}, files...)...)
+	return err
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTasks(t *testing.T) {
+	raw := []interface{}{
+		map[interface{}]interface{}{"name": "gofmt", "run": "gofmt -l {files}", "glob": []interface{}{"**/*.go"}, "restage": true},
+		map[string]interface{}{"run": "go vet ./..."},
+		map[string]interface{}{"name": "no command"},
+		"invalid",
+	}
+
+	expected := []Task{
+		{Name: "gofmt", Run: "gofmt -l {files}", Glob: []string{"**/*.go"}, Restage: true},
+		{Name: "task 2", Run: "go vet ./...", Glob: []string{}},
+	}
+	if tasks := ParseTasks(raw); !reflect.DeepEqual(tasks, expected) {
+		t.Errorf("Expected %+v, got: %+v", expected, tasks)
+	}
+}
+
+func TestTaskCommand(t *testing.T) {
+	task := Task{Run: "gofmt -l {files}", Glob: []string{"**/*.go"}}
+	staged := []string{"main.go", "cmd/root.go", "README.md", "it's.go"}
+
+	files := task.MatchFiles(staged)
+	if !reflect.DeepEqual(files, []string{"main.go", "cmd/root.go", "it's.go"}) {
+		t.Errorf("Expected go files, got: %v", files)
+	}
+	if cmd := task.Command(files); cmd != `gofmt -l 'main.go' 'cmd/root.go' 'it'\''s.go'` {
+		t.Errorf("Expected quoted files, got: %s", cmd)
+	}
+	if files := (Task{Run: "true"}).MatchFiles(staged); len(files) != len(staged) {
+		t.Errorf("Expected all files without glob, got: %v", files)
+	}
+}
+
+func TestRunTasks(t *testing.T) {
+	tasks := []Task{
+		{Name: "echo", Run: "echo {files}"},
+		{Name: "fail", Run: "echo oops >&2; exit 1"},
+		{Name: "skip", Run: "true", Glob: []string{"*.md"}},
+	}
+
+	results := RunTasks(tasks, []string{"a.go", "b.go"}, 2)
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got: %v", results)
+	}
+	if r := results[0]; r.Err != nil || strings.TrimSpace(r.Output) != "a.go b.go" {
+		t.Errorf("Expected echo output, got: %q, %v", r.Output, r.Err)
+	}
+	if r := results[1]; r.Err == nil || strings.TrimSpace(r.Output) != "oops" {
+		t.Errorf("Expected failure with captured stderr, got: %q, %v", r.Output, r.Err)
+	}
+	if !results[2].Skipped {
+		t.Error("Expected task without matching files skipped")
+	}
+
+	var out bytes.Buffer
+	if ok := PrintTaskResults(&out, results); ok {
+		t.Error("Expected failed tasks reported")
+	}
+	for _, expected := range []string{"✓ echo", "✗ fail", "  oops", "- skip: skipped"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, out.String())
+		}
+	}
+}
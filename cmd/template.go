@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// TmplData data of commit message template, fields of CommitMsg
+// are accessible directly, i.e. `{{.Type}}`, `{{.Branch}}`
+type TmplData struct {
+	*CommitMsg
+	Branch  string   // current branch, "" if detached
+	Tickets []string // ticket ids matched in branch name
+	Emoji   string   // emoji of type, see TypeEmoji
+	Author  string   // `Name <email>` of commit author
+}
+
+// MsgTmpl template text of commit type, gitwok.commit.templates.<type>
+// over gitwok.commit.template over the built-in CommitMsgTmpl
+// @return custom {bool} false if built-in template
+func MsgTmpl(cmtType string) (string, bool) {
+	if text := viper.GetStringMapString("gitwok.commit.templates")[strings.ToLower(cmtType)]; text != "" {
+		return text, true
+	}
+	if text := viper.GetString("gitwok.commit.template"); text != "" {
+		return text, true
+	}
+	return CommitMsgTmpl, false
+}
+
+// NewTmplData template data of commit msg with extra fields from git and config
+func NewTmplData(cm *CommitMsg, git *Git) TmplData {
+	return TmplData{
+		CommitMsg: cm,
+		Branch:    git.CurrentBranch(),
+		Tickets:   BranchTickets(git),
+		Emoji:     TypeEmoji(cm.Type),
+		Author:    git.AuthorIdent(),
+	}
+}
+
+// RenderTmpl execute commit message template text with data
+func RenderTmpl(text string, data TmplData) (string, error) {
+	tmpl, err := newTmpl("commitmsg").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("commit template is invalid: %v", err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("commit template is invalid: %v", err)
+	}
+	return sb.String(), nil
+}
+
+// roundTrip check rendered message still parses as a conventional
+// commit keeping type, scope and breaking change of the commit msg
+func roundTrip(cm *CommitMsg, msg string) error {
+	parsed, ok := ParseCommitMsg(msg)
+	if !ok {
+		return fmt.Errorf("commit template output is not a conventional commit: %q", strings.SplitN(msg, "\n", 2)[0])
+	}
+	if parsed.Type != cm.Type || parsed.Scope != cm.Scope || parsed.HasBrkChange != cm.HasBrkChange {
+		return fmt.Errorf("commit template output changes type, scope or breaking change: %q", parsed.Header())
+	}
+	if ok, errMsg := parsed.Validate(); !ok {
+		return fmt.Errorf("commit template output is invalid: %s", errMsg)
+	}
+	return nil
+}
+
+// Render format commit msg by configured template of its type,
+// built-in template places emoji of type if any, custom templates
+// place `{{.Emoji}}` themselves and are round-trip validated
+func (cm *CommitMsg) Render(git *Git) (string, error) {
+	text, custom := MsgTmpl(cm.Type)
+	if !custom {
+		return PlaceEmoji(cm.ToString(), TypeEmoji(cm.Type)), nil
+	}
+
+	msg, err := RenderTmpl(text, NewTmplData(cm, git))
+	if err != nil {
+		return "", err
+	}
+	return msg, roundTrip(cm, msg)
+}
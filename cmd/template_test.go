@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestMsgTmpl(t *testing.T) {
+	defer viper.Set("gitwok.commit.template", "")
+	defer viper.Set("gitwok.commit.templates", map[string]string{})
+
+	if text, custom := MsgTmpl("feat"); custom || text != CommitMsgTmpl {
+		t.Errorf("Expected built-in template, got: %q", text)
+	}
+
+	viper.Set("gitwok.commit.template", "global")
+	viper.Set("gitwok.commit.templates", map[string]string{"fix": "per type"})
+	if text, custom := MsgTmpl("fix"); !custom || text != "per type" {
+		t.Errorf("Expected per type template, got: %q", text)
+	}
+	if text, custom := MsgTmpl("feat"); !custom || text != "global" {
+		t.Errorf("Expected global template, got: %q", text)
+	}
+}
+
+func TestRenderTmpl(t *testing.T) {
+	data := TmplData{
+		CommitMsg: &CommitMsg{Type: "feat", Scope: "api", Description: "add login", Footers: []string{"Refs: ABC-1"}},
+		Branch:    "feature/ABC-1-login",
+		Tickets:   []string{"ABC-1"},
+		Emoji:     "✨",
+		Author:    "Jane Doe <jane@example.com>",
+	}
+
+	text := "{{.Type}}({{.Scope}}): {{.Emoji}} {{.Description}}\n\nOn {{.Branch}} for {{join .Tickets \", \"}} by {{.Author}}\n{{range .Footers}}\n{{.}}{{end}}\n"
+	msg, err := RenderTmpl(text, data)
+	expected := "feat(api): ✨ add login\n\nOn feature/ABC-1-login for ABC-1 by Jane Doe <jane@example.com>\n\nRefs: ABC-1\n"
+	if err != nil || msg != expected {
+		t.Errorf("Expected %q, got: %q, %v", expected, msg, err)
+	}
+
+	if _, err := RenderTmpl("{{.Type", data); err == nil {
+		t.Error("unparsable template should error")
+	}
+	if _, err := RenderTmpl("{{.Unknown}}", data); err == nil {
+		t.Error("unknown field should error")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	cm := &CommitMsg{Type: "feat", Scope: "api", HasBrkChange: true, Description: "add login"}
+
+	testcases := []struct {
+		msg string
+		ok  bool
+	}{
+		{"feat(api)!: ✨ add login\n", true},
+		{"feat(api)!: add login\n\nbody\n\nRefs: ABC-1\n", true},
+		{"✨ add login\n", false},
+		{"feat(web)!: add login\n", false},
+		{"feat(api): add login\n", false},
+		{"fix(api)!: add login\n", false},
+		{"feat(api)!: add login\n\nBREAKING CHANGE #12\n", false},
+	}
+
+	for _, tc := range testcases {
+		if err := roundTrip(cm, tc.msg); (err == nil) != tc.ok {
+			t.Errorf("Round trip of %q expected ok %v, got: %v", tc.msg, tc.ok, err)
+		}
+	}
+}
+
+func TestRenderBuiltIn(t *testing.T) {
+	cm := &CommitMsg{Type: "fix", Description: "typo"}
+	if msg, err := cm.Render(&Git{}); err != nil || msg != cm.ToString() {
+		t.Errorf("Expected built-in format %q, got: %q, %v", cm.ToString(), msg, err)
+	}
+
+	viper.Set("gitwok.commit.template", "{{.Description}}\n")
+	defer viper.Set("gitwok.commit.template", "")
+	if _, err := cm.Render(&Git{}); err == nil || !strings.Contains(err.Error(), "not a conventional commit") {
+		t.Errorf("non conventional template output should be rejected, got: %v", err)
+	}
+}
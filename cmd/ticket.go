@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// TicketTargetFooter add ticket as footer, i.e. `Refs: PROJ-1234`
+	TicketTargetFooter = "footer"
+	// TicketTargetScope use ticket as scope if scope is empty
+	TicketTargetScope = "scope"
+	// TicketTargetDesc prefix description with ticket
+	TicketTargetDesc = "description"
+)
+
+// CurrentBranch short name of the checked out branch, "" if detached or not in a repo
+func (git *Git) CurrentBranch() string {
+	branch, err := git.run("symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return branch
+}
+
+// MatchTickets extract unique ticket ids from branch name by regexes,
+// the first submatch is taken if any, otherwise the whole match
+func MatchTickets(branch string, patterns []string) []string {
+	tickets := []string{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Invalid ticket pattern %q: %v", p, err))
+			continue
+		}
+		for _, m := range re.FindAllStringSubmatch(branch, -1) {
+			ticket := m[0]
+			if len(m) > 1 && m[1] != "" {
+				ticket = m[1]
+			}
+			if !containsStr(tickets, ticket) {
+				tickets = append(tickets, ticket)
+			}
+		}
+	}
+	return tickets
+}
+
+// BranchTickets ticket ids of the current branch by gitwok.ticket.pattern
+func BranchTickets(git *Git) []string {
+	patterns := viper.GetStringSlice("gitwok.ticket.pattern")
+	if len(patterns) == 0 {
+		return []string{}
+	}
+	return MatchTickets(git.CurrentBranch(), patterns)
+}
+
+// branchTickets tickets of the branch pushed if linted from history,
+// else of the current branch
+func (cm *CommitMsg) branchTickets() []string {
+	if cm.branch == "" {
+		return BranchTickets(&Git{})
+	}
+	return MatchTickets(cm.branch, viper.GetStringSlice("gitwok.ticket.pattern"))
+}
+
+// HasTicket check if msg references ticket as a whole word,
+// i.e. `PROJ-1` is not referenced by `PROJ-12`
+func HasTicket(msg, ticket string) bool {
+	re := regexp.MustCompile(`(^|[^\w])` + regexp.QuoteMeta(ticket) + `($|[^\w])`)
+	return re.MatchString(msg)
+}
+
+// FillTickets add tickets missing in commit msg to gitwok.ticket.target
+func (cm *CommitMsg) FillTickets(tickets []string) {
+	for _, ticket := range tickets {
+		if HasTicket(cm.ToString(), ticket) {
+			continue
+		}
+
+		switch viper.GetString("gitwok.ticket.target") {
+		case TicketTargetScope:
+			if cm.Scope == "" {
+				cm.Scope = ticket
+			} else {
+				cm.Scope = JoinScopes(append(SplitScopes(cm.Scope), ticket))
+			}
+		case TicketTargetDesc:
+			cm.Description = ticket + " " + cm.Description
+		default:
+			cm.Footers = append(cm.Footers, viper.GetString("gitwok.ticket.token")+FSepColonSpace+ticket)
+		}
+	}
+}
+
+// missingTickets tickets of branch not referenced in commit msg
+func (cm *CommitMsg) missingTickets(tickets []string) []string {
+	missing := []string{}
+	for _, ticket := range tickets {
+		if !HasTicket(cm.ToString(), ticket) {
+			missing = append(missing, ticket)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestMatchTickets(t *testing.T) {
+	var tests = []struct {
+		branch   string
+		patterns []string
+		expected []string
+	}{
+		{"feature/PROJ-1234-login", []string{`[A-Z][A-Z0-9]+-\d+`}, []string{"PROJ-1234"}},
+		{"feature/PROJ-1-PROJ-2-PROJ-1", []string{`[A-Z][A-Z0-9]+-\d+`}, []string{"PROJ-1", "PROJ-2"}},
+		{"fix/issue-42", []string{`issue-(\d+)`}, []string{"42"}},
+		{"main", []string{`[A-Z][A-Z0-9]+-\d+`}, []string{}},
+		{"feature/PROJ-1", []string{`[`}, []string{}},
+	}
+
+	for _, test := range tests {
+		if got := MatchTickets(test.branch, test.patterns); !CompareStrSlices(got, test.expected) {
+			t.Errorf("MatchTickets with %q failed, expected: %v, got: %v", test.branch, test.expected, got)
+		}
+	}
+}
+
+func TestFillTickets(t *testing.T) {
+	viper.Reset()
+	initDefaults()
+	defer viper.Reset()
+
+	cm := makeCommitMsg("feat", "", false, "login", "", []string{})
+	cm.FillTickets([]string{"PROJ-1", "PROJ-2"})
+	if expected := []string{"Refs: PROJ-1", "Refs: PROJ-2"}; !CompareStrSlices(cm.Footers, expected) {
+		t.Errorf("FillTickets footer failed, expected: %v, got: %v", expected, cm.Footers)
+	}
+
+	// referenced tickets are not filled again
+	cm.FillTickets([]string{"PROJ-1"})
+	if len(cm.Footers) != 2 {
+		t.Errorf("FillTickets failed, expected no duplicated ticket, got: %v", cm.Footers)
+	}
+
+	viper.Set("gitwok.ticket.target", TicketTargetScope)
+	cm = makeCommitMsg("feat", "", false, "login", "", []string{})
+	cm.FillTickets([]string{"PROJ-1"})
+	if cm.Scope != "PROJ-1" {
+		t.Errorf("FillTickets scope failed, expected: %q, got: %q", "PROJ-1", cm.Scope)
+	}
+
+	viper.Set("gitwok.ticket.target", TicketTargetDesc)
+	cm = makeCommitMsg("feat", "", false, "login", "", []string{})
+	cm.FillTickets([]string{"PROJ-1"})
+	if cm.Description != "PROJ-1 login" {
+		t.Errorf("FillTickets description failed, expected: %q, got: %q", "PROJ-1 login", cm.Description)
+	}
+
+	if missing := cm.missingTickets([]string{"PROJ-1", "PROJ-2"}); !CompareStrSlices(missing, []string{"PROJ-2"}) {
+		t.Errorf("missingTickets failed, expected: %v, got: %v", []string{"PROJ-2"}, missing)
+	}
+}
+
+func TestHasTicket(t *testing.T) {
+	tests := []struct {
+		msg      string
+		ticket   string
+		expected bool
+	}{
+		{"feat: login\n\nRefs: PROJ-1", "PROJ-1", true},
+		{"feat(PROJ-1): login", "PROJ-1", true},
+		{"PROJ-1 login", "PROJ-1", true},
+		{"feat: login\n\nRefs: PROJ-12", "PROJ-1", false},
+		{"feat: login\n\nRefs: XPROJ-1", "PROJ-1", false},
+		{"fix: typo\n\nCloses #12", "#12", true},
+		{"fix: typo\n\nCloses #123", "#12", false},
+	}
+
+	for _, test := range tests {
+		if got := HasTicket(test.msg, test.ticket); got != test.expected {
+			t.Errorf("HasTicket(%q, %q) failed, expected: %v, got: %v", test.msg, test.ticket, test.expected, got)
+		}
+	}
+}